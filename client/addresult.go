@@ -0,0 +1,179 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	jsonresp "github.com/sylabs/json-resp"
+)
+
+// AddStatus describes how the Key Service processed a submitted key.
+type AddStatus string
+
+const (
+	// AddStatusInserted indicates a key was not previously known to the Key Service.
+	AddStatusInserted AddStatus = "inserted"
+	// AddStatusUpdated indicates a key was merged with a key already known to the Key Service.
+	AddStatusUpdated AddStatus = "updated"
+	// AddStatusIgnored indicates a key was already known to the Key Service, and was not modified.
+	AddStatusIgnored AddStatus = "ignored"
+	// AddStatusUnknown indicates a key was reported by the Key Service without a status.
+	AddStatusUnknown AddStatus = "unknown"
+)
+
+// AddedKey describes a key processed by the Key Service.
+type AddedKey struct {
+	// Upper-case hexadecimal fingerprint of the key.
+	Fingerprint string
+	// Status of the key.
+	Status AddStatus
+}
+
+// AddResult describes the result of submitting a keyring to the Key Service.
+type AddResult struct {
+	// Keys reported by the Key Service. Key servers that do not report the keys they processed
+	// result in an empty list.
+	Keys []AddedKey
+}
+
+// Fingerprints returns the fingerprints of keys in r with the specified status.
+func (r *AddResult) Fingerprints(status AddStatus) []string {
+	var fps []string
+	for _, k := range r.Keys {
+		if k.Status == status {
+			fps = append(fps, k.Fingerprint)
+		}
+	}
+	return fps
+}
+
+// addResponse is the machine readable response to a "/pks/add" request, as returned by the Sylabs
+// Key Service (wrapped in a JSON response envelope) and Hockeypuck.
+type addResponse struct {
+	Inserted []string `json:"inserted"`
+	Updated  []string `json:"updated"`
+	Ignored  []string `json:"ignored"`
+}
+
+// result returns an AddResult corresponding to ar.
+func (ar addResponse) result() *AddResult {
+	var r AddResult
+	for _, l := range []struct {
+		fps    []string
+		status AddStatus
+	}{
+		{ar.Inserted, AddStatusInserted},
+		{ar.Updated, AddStatusUpdated},
+		{ar.Ignored, AddStatusIgnored},
+	} {
+		for _, fp := range l.fps {
+			r.Keys = append(r.Keys, AddedKey{Fingerprint: normalizeFingerprint(fp), Status: l.status})
+		}
+	}
+	return &r
+}
+
+// normalizeFingerprint returns fp in upper-case hexadecimal, without prefix or whitespace.
+func normalizeFingerprint(fp string) string {
+	fp = strings.Join(strings.Fields(fp), "")
+	fp = strings.TrimPrefix(strings.TrimPrefix(fp, "0x"), "0X")
+	return strings.ToUpper(fp)
+}
+
+// parseAddResult parses the body of a successful response to a "/pks/add" request.
+//
+// JSON bodies are expected to contain the machine readable form, optionally wrapped in a JSON
+// response envelope. Other bodies, such as the HTML returned by SKS and Hockeypuck, are scanned for
+// fingerprints, the status of which is inferred from the preceding text.
+func parseAddResult(body []byte) (*AddResult, error) {
+	if b := bytes.TrimSpace(body); len(b) > 0 && b[0] == '{' {
+		return parseAddResultJSON(b)
+	}
+	return parseAddResultText(string(body)), nil
+}
+
+// parseAddResultJSON parses a machine readable response body b.
+func parseAddResultJSON(b []byte) (*AddResult, error) {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse add response: %w", err)
+	}
+
+	var ar addResponse
+	if envelope.Data != nil {
+		if err := jsonresp.ReadResponse(bytes.NewReader(b), &ar); err != nil {
+			return nil, fmt.Errorf("failed to parse add response: %w", err)
+		}
+	} else if err := json.Unmarshal(b, &ar); err != nil {
+		return nil, fmt.Errorf("failed to parse add response: %w", err)
+	}
+
+	return ar.result(), nil
+}
+
+// reFingerprint matches version 4 fingerprints, optionally formatted in space-separated groups of
+// four, and version 6 fingerprints.
+var reFingerprint = regexp.MustCompile(`(?i)\b(?:0x)?((?:[0-9a-f]{4} {0,2}){9}[0-9a-f]{4}|[0-9a-f]{64})\b`)
+
+// addStatusKeywords maps keywords found in human readable responses to an AddStatus.
+var addStatusKeywords = []struct {
+	keyword string
+	status  AddStatus
+}{
+	{"ignored", AddStatusIgnored},
+	{"unchanged", AddStatusIgnored},
+	{"updated", AddStatusUpdated},
+	{"merged", AddStatusUpdated},
+	{"inserted", AddStatusInserted},
+	{"added", AddStatusInserted},
+	{"new", AddStatusInserted},
+}
+
+// parseAddResultText scans a human readable response body for fingerprints.
+func parseAddResultText(body string) *AddResult {
+	var r AddResult
+
+	text := reHTMLTag.ReplaceAllString(body, "\n")
+
+	status := AddStatusUnknown
+	for _, line := range strings.Split(text, "\n") {
+		matches := reFingerprint.FindAllStringSubmatchIndex(line, -1)
+
+		// The status is inferred from text preceding the first fingerprint on a line.
+		prefix := line
+		if len(matches) > 0 {
+			prefix = line[:matches[0][0]]
+		}
+		for _, w := range strings.FieldsFunc(strings.ToLower(prefix), isNotLetter) {
+			for _, kw := range addStatusKeywords {
+				if w == kw.keyword {
+					status = kw.status
+				}
+			}
+		}
+
+		for _, m := range matches {
+			r.Keys = append(r.Keys, AddedKey{
+				Fingerprint: normalizeFingerprint(line[m[2]:m[3]]),
+				Status:      status,
+			})
+		}
+	}
+
+	return &r
+}
+
+// isNotLetter returns true if r is not an ASCII letter.
+func isNotLetter(r rune) bool {
+	return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+}
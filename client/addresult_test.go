@@ -0,0 +1,103 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAddResult(t *testing.T) {
+	const (
+		fp1 = "6927A60652A6966A58BB936819915CCF6F9F5B7A"
+		fp2 = "116986D84F9738584E2403AD58A2E029D2B4302C"
+		fp3 = "0123456789ABCDEF0123456789ABCDEF01234567"
+	)
+
+	tests := []struct {
+		name     string
+		body     string
+		wantErr  bool
+		wantKeys []AddedKey
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:    "MalformedJSON",
+			body:    "{",
+			wantErr: true,
+		},
+		{
+			name: "Hockeypuck",
+			body: `{"inserted":["` + fp1 + `"],"updated":["0x` + fp2 + `"],"ignored":["0123456789abcdef0123456789abcdef01234567"]}`,
+			wantKeys: []AddedKey{
+				{fp1, AddStatusInserted},
+				{fp2, AddStatusUpdated},
+				{fp3, AddStatusIgnored},
+			},
+		},
+		{
+			name: "Sylabs",
+			body: `{"data":{"inserted":["` + fp1 + `"],"updated":["` + fp2 + `"]}}`,
+			wantKeys: []AddedKey{
+				{fp1, AddStatusInserted},
+				{fp2, AddStatusUpdated},
+			},
+		},
+		{
+			name:    "SylabsError",
+			body:    `{"data":{},"error":{"code":400,"message":"bad"}}`,
+			wantErr: true,
+		},
+		{
+			name: "HTML",
+			body: `<html><body><h2>Inserted:</h2><ul><li>` + fp1 + `</li></ul>` +
+				`<h2>Updated:</h2><ul><li>` + fp2 + `</li></ul>` +
+				`<h2>Ignored:</h2><ul><li>` + fp3 + `</li></ul></body></html>`,
+			wantKeys: []AddedKey{
+				{fp1, AddStatusInserted},
+				{fp2, AddStatusUpdated},
+				{fp3, AddStatusIgnored},
+			},
+		},
+		{
+			name: "TextGrouped",
+			body: "Key block merged: 6927 A606 52A6 966A 58BB  9368 1991 5CCF 6F9F 5B7A\n",
+			wantKeys: []AddedKey{
+				{fp1, AddStatusUpdated},
+			},
+		},
+		{
+			name: "TextNoStatus",
+			body: "Key block processed.\n" + fp1 + "\n",
+			wantKeys: []AddedKey{
+				{fp1, AddStatusUnknown},
+			},
+		},
+		{
+			name: "SKS",
+			body: "<html><head><title>Keys added</title></head><body>" +
+				"<h2>Key block added to key server database.</h2>" +
+				"<p>New public keys added: <br/></p></body></html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseAddResult([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got err %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil {
+				if got, want := r.Keys, tt.wantKeys; !reflect.DeepEqual(got, want) {
+					t.Errorf("got keys %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
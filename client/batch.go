@@ -0,0 +1,285 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidResumeToken is returned when a resume token is invalid, or does not correspond to the
+// keyring and options supplied.
+var ErrInvalidResumeToken = errors.New("invalid resume token")
+
+const defaultAddBatchConcurrency = 4
+
+// AddBatchOptions describes options for PKSAddBatch.
+type AddBatchOptions struct {
+	// Maximum number of concurrent uploads (defaults to 4 if zero).
+	Concurrency int
+	// Maximum size in bytes of the key material submitted with a single request. If zero, each key
+	// is submitted individually. Keys larger than MaxChunkSize are always submitted individually.
+	MaxChunkSize int
+	// Resume token returned by a previous call that did not complete. Chunks processed by the
+	// previous call are not submitted again.
+	ResumeToken string
+}
+
+// AddBatchItem describes the outcome of submitting a chunk of a keyring.
+type AddBatchItem struct {
+	// Index of the chunk within the keyring.
+	Index int
+	// Upper-case hexadecimal fingerprints of the primary keys contained in the chunk.
+	Fingerprints []string
+	// Result reported by the Key Service (only set for accepted chunks).
+	Result *AddResult
+	// Error encountered submitting the chunk, if any. For rejected chunks, the error wraps an
+	// HTTPError.
+	Err error
+}
+
+// AddBatchReport describes the outcome of PKSAddBatch.
+type AddBatchReport struct {
+	// Chunks accepted by the Key Service.
+	Accepted []AddBatchItem
+	// Chunks rejected by the Key Service.
+	Rejected []AddBatchItem
+	// Chunks that were not submitted because the resume token indicated they were processed by a
+	// previous call.
+	Resumed []AddBatchItem
+	// Chunks that were not submitted, for which no response was received, or which failed with a
+	// temporary error (see IsTemporary).
+	Skipped []AddBatchItem
+	// Token that can be supplied in AddBatchOptions to resume processing, or empty if every chunk
+	// was accepted or rejected.
+	ResumeToken string
+}
+
+// keyChunk describes a chunk of a keyring to be submitted with a single request.
+type keyChunk struct {
+	keys   []transferableKey
+	size   int
+	state  chunkState
+	result *AddResult
+	err    error
+}
+
+// chunkState describes the processing state of a keyChunk.
+type chunkState int
+
+const (
+	chunkPending chunkState = iota
+	chunkAccepted
+	chunkRejected
+	chunkResumed
+	chunkSkipped
+)
+
+// item returns an AddBatchItem describing chunk i.
+func (kc *keyChunk) item(i int) AddBatchItem {
+	fps := make([]string, 0, len(kc.keys))
+	for _, k := range kc.keys {
+		fps = append(fps, fmt.Sprintf("%X", k.fingerprint()))
+	}
+	return AddBatchItem{Index: i, Fingerprints: fps, Result: kc.result, Err: kc.err}
+}
+
+// hasSecret returns true if k contains a secret key or secret subkey packet.
+func (k transferableKey) hasSecret() bool {
+	for _, p := range k {
+		if p.tag == tagSecretKey || p.tag == tagSecretSubkey {
+			return true
+		}
+	}
+	return false
+}
+
+// chunkKeys groups keys into chunks of at most maxSize bytes, preserving order. If maxSize is zero,
+// each key is placed in its own chunk.
+func chunkKeys(keys []transferableKey, maxSize int) []*keyChunk {
+	var chunks []*keyChunk
+
+	for _, k := range keys {
+		n := len(k.bytes())
+
+		if len(chunks) > 0 && maxSize > 0 {
+			if last := chunks[len(chunks)-1]; last.size+n <= maxSize {
+				last.keys = append(last.keys, k)
+				last.size += n
+				continue
+			}
+		}
+		chunks = append(chunks, &keyChunk{keys: []transferableKey{k}, size: n})
+	}
+
+	return chunks
+}
+
+// batchDigest returns a digest identifying the chunks derived from keyText with maxSize.
+func batchDigest(keyText string, maxSize int) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n%s", maxSize, keyText)
+	return fmt.Sprintf("%x", h.Sum(nil)[:8])
+}
+
+// encodeResumeToken returns a resume token recording that the first n chunks identified by digest
+// have been processed.
+func encodeResumeToken(n int, digest string) string {
+	return fmt.Sprintf("%d.%s", n, digest)
+}
+
+// decodeResumeToken returns the number of chunks processed according to token. If token does not
+// correspond to digest, an error wrapping ErrInvalidResumeToken is returned.
+func decodeResumeToken(token, digest string, chunks int) (int, error) {
+	s, d, ok := strings.Cut(token, ".")
+	if !ok || d != digest {
+		return 0, fmt.Errorf("%w", ErrInvalidResumeToken)
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > chunks {
+		return 0, fmt.Errorf("%w", ErrInvalidResumeToken)
+	}
+	return n, nil
+}
+
+// PKSAddBatch submits the keys contained in keyText, which may be ASCII armored or binary, to the
+// Key Service in chunks, according to opts. If opts is nil, default options are used. Chunks are
+// submitted in parallel, as specified in section 4 of the OpenPGP HTTP Keyserver Protocol (HKP)
+// specification. The context controls the lifetime of the requests.
+//
+// The returned report describes which chunks were accepted, rejected, resumed or skipped. If the
+// report contains a resume token, it can be supplied in a subsequent call with the same keyText and
+// opts.MaxChunkSize to continue where processing stopped. Chunks that completed out of order
+// before processing stopped may be submitted again.
+//
+// If keyText cannot be parsed, or contains secret key material, an error wrapping
+// ErrInvalidKeyText is returned. If the context is cancelled, an error wrapping the context error
+// is returned along with the report.
+func (c *Client) PKSAddBatch(ctx context.Context, keyText string, opts *AddBatchOptions) (*AddBatchReport, error) {
+	var o AddBatchOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultAddBatchConcurrency
+	}
+
+	keys, err := parseKeyring([]byte(keyText))
+	if err != nil || len(keys) == 0 {
+		return nil, fmt.Errorf("%w", ErrInvalidKeyText)
+	}
+	for _, k := range keys {
+		if k.hasSecret() {
+			return nil, fmt.Errorf("%w: secret key material not permitted", ErrInvalidKeyText)
+		}
+	}
+
+	chunks := chunkKeys(keys, o.MaxChunkSize)
+	digest := batchDigest(keyText, o.MaxChunkSize)
+
+	start := 0
+	if o.ResumeToken != "" {
+		if start, err = decodeResumeToken(o.ResumeToken, digest, len(chunks)); err != nil {
+			return nil, err
+		}
+	}
+	for _, kc := range chunks[:start] {
+		kc.state = chunkResumed
+	}
+
+	ch := make(chan *keyChunk)
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.Concurrency && i < len(chunks)-start; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for kc := range ch {
+				kc.state, kc.result, kc.err = c.addChunk(ctx, kc)
+			}
+		}()
+	}
+
+feed:
+	for _, kc := range chunks[start:] {
+		select {
+		case ch <- kc:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(ch)
+	wg.Wait()
+
+	report := newAddBatchReport(chunks, digest)
+
+	if err := ctx.Err(); err != nil {
+		return report, fmt.Errorf("%w", err)
+	}
+	return report, nil
+}
+
+// addChunk submits kc to the Key Service, and returns the resulting state of the chunk, along with
+// the result reported by the Key Service. Chunks that failed with a temporary error, such as when the
+// Key Service is limiting requests or unavailable, are skipped, so that they are submitted again
+// when processing is resumed.
+func (c *Client) addChunk(ctx context.Context, kc *keyChunk) (chunkState, *AddResult, error) {
+	var k transferableKey
+	for _, key := range kc.keys {
+		k = append(k, key...)
+	}
+
+	text, err := k.armor()
+	if err != nil {
+		return chunkSkipped, nil, err
+	}
+
+	r, err := c.PKSAddWithResult(ctx, text)
+	if err != nil {
+		if httpErr := (*HTTPError)(nil); errors.As(err, &httpErr) && !IsTemporary(err) {
+			return chunkRejected, nil, err
+		}
+		return chunkSkipped, nil, err
+	}
+	return chunkAccepted, r, nil
+}
+
+// newAddBatchReport returns a report describing chunks.
+func newAddBatchReport(chunks []*keyChunk, digest string) *AddBatchReport {
+	var r AddBatchReport
+
+	// The resume point is the first chunk that was not accepted, rejected or resumed.
+	resume := len(chunks)
+
+	for i, kc := range chunks {
+		switch kc.state {
+		case chunkAccepted:
+			r.Accepted = append(r.Accepted, kc.item(i))
+		case chunkRejected:
+			r.Rejected = append(r.Rejected, kc.item(i))
+		case chunkResumed:
+			r.Resumed = append(r.Resumed, kc.item(i))
+		default:
+			r.Skipped = append(r.Skipped, kc.item(i))
+
+			if i < resume {
+				resume = i
+			}
+		}
+	}
+
+	if resume < len(chunks) {
+		r.ResumeToken = encodeResumeToken(resume, digest)
+	}
+	return &r
+}
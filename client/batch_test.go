@@ -0,0 +1,272 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// MockPKSAddBatch accepts submitted keys, except those with a rejected fingerprint.
+type MockPKSAddBatch struct {
+	t           *testing.T
+	rejected    string // Fingerprint of key to reject.
+	throttled   string // Fingerprint of key to respond to with status 429.
+	unavailable string // Fingerprint of key to respond to with status 503.
+
+	mu       sync.Mutex
+	requests int
+}
+
+func (m *MockPKSAddBatch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	m.requests++
+	m.mu.Unlock()
+
+	if got, want := r.URL.Path, pathPKSAdd; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		m.t.Fatalf("failed to parse form: %v", err)
+	}
+
+	keys, err := parseKeyring([]byte(r.Form.Get("keytext")))
+	if err != nil {
+		m.t.Errorf("failed to parse key text: %v", err)
+	}
+
+	for _, k := range keys {
+		switch fmt.Sprintf("%X", k.fingerprint()) {
+		case m.rejected:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		case m.throttled:
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		case m.unavailable:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+	}
+}
+
+// batchFingerprints returns the fingerprints contained in items.
+func batchFingerprints(items []AddBatchItem) []string {
+	var fps []string
+	for _, item := range items {
+		fps = append(fps, item.Fingerprints...)
+	}
+	return fps
+}
+
+func TestPKSAddBatch(t *testing.T) {
+	keyText := readTestKey(t, "alice.asc") + readTestKey(t, "bob.asc")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name         string
+		ctx          context.Context //nolint:containedctx
+		keyText      string
+		rejected     string
+		throttled    string
+		unavailable  string
+		opts         *AddBatchOptions
+		wantErr      error
+		wantAccepted []string
+		wantRejected []string
+		wantResumed  []string
+		wantSkipped  []string
+		wantSkipErr  error
+		wantResume   string
+		wantRequests int
+	}{
+		{
+			name:         "DefaultOptions",
+			ctx:          context.Background(),
+			keyText:      keyText,
+			wantAccepted: []string{aliceFingerprint, bobFingerprint},
+			wantRequests: 2,
+		},
+		{
+			name:         "Chunked",
+			ctx:          context.Background(),
+			keyText:      keyText,
+			opts:         &AddBatchOptions{MaxChunkSize: 1 << 20},
+			wantAccepted: []string{aliceFingerprint, bobFingerprint},
+			wantRequests: 1,
+		},
+		{
+			name:         "ChunkTooSmall",
+			ctx:          context.Background(),
+			keyText:      keyText,
+			opts:         &AddBatchOptions{MaxChunkSize: 1},
+			wantAccepted: []string{aliceFingerprint, bobFingerprint},
+			wantRequests: 2,
+		},
+		{
+			name:         "Rejected",
+			ctx:          context.Background(),
+			keyText:      keyText,
+			rejected:     bobFingerprint,
+			wantAccepted: []string{aliceFingerprint},
+			wantRejected: []string{bobFingerprint},
+			wantRequests: 2,
+		},
+		{
+			name:         "Resume",
+			ctx:          context.Background(),
+			keyText:      keyText,
+			opts:         &AddBatchOptions{ResumeToken: encodeResumeToken(1, batchDigest(keyText, 0))},
+			wantAccepted: []string{bobFingerprint},
+			wantResumed:  []string{aliceFingerprint},
+			wantRequests: 1,
+		},
+		{
+			name:         "Throttled",
+			ctx:          context.Background(),
+			keyText:      keyText,
+			throttled:    aliceFingerprint,
+			opts:         &AddBatchOptions{Concurrency: 1},
+			wantAccepted: []string{bobFingerprint},
+			wantSkipped:  []string{aliceFingerprint},
+			wantSkipErr:  &HTTPError{code: http.StatusTooManyRequests},
+			wantResume:   encodeResumeToken(0, batchDigest(keyText, 0)),
+			wantRequests: 2,
+		},
+		{
+			name:         "Unavailable",
+			ctx:          context.Background(),
+			keyText:      keyText,
+			unavailable:  aliceFingerprint,
+			opts:         &AddBatchOptions{Concurrency: 1},
+			wantAccepted: []string{bobFingerprint},
+			wantSkipped:  []string{aliceFingerprint},
+			wantSkipErr:  &HTTPError{code: http.StatusServiceUnavailable},
+			wantResume:   encodeResumeToken(0, batchDigest(keyText, 0)),
+			wantRequests: 2,
+		},
+		{
+			name:         "ResumeThrottled",
+			ctx:          context.Background(),
+			keyText:      keyText,
+			throttled:    bobFingerprint,
+			opts:         &AddBatchOptions{ResumeToken: encodeResumeToken(1, batchDigest(keyText, 0))},
+			wantResumed:  []string{aliceFingerprint},
+			wantSkipped:  []string{bobFingerprint},
+			wantSkipErr:  &HTTPError{code: http.StatusTooManyRequests},
+			wantResume:   encodeResumeToken(1, batchDigest(keyText, 0)),
+			wantRequests: 1,
+		},
+		{
+			name:    "ResumeTokenMismatch",
+			ctx:     context.Background(),
+			keyText: keyText,
+			opts:    &AddBatchOptions{ResumeToken: encodeResumeToken(1, batchDigest(keyText, 1))},
+			wantErr: ErrInvalidResumeToken,
+		},
+		{
+			name:    "ResumeTokenOutOfRange",
+			ctx:     context.Background(),
+			keyText: keyText,
+			opts:    &AddBatchOptions{ResumeToken: encodeResumeToken(3, batchDigest(keyText, 0))},
+			wantErr: ErrInvalidResumeToken,
+		},
+		{
+			name:        "ContextCanceled",
+			ctx:         cancelled,
+			keyText:     keyText,
+			wantErr:     context.Canceled,
+			wantSkipped: []string{aliceFingerprint, bobFingerprint},
+			wantResume:  encodeResumeToken(0, batchDigest(keyText, 0)),
+		},
+		{
+			name:    "InvalidKeyText",
+			ctx:     context.Background(),
+			keyText: "blah",
+			wantErr: ErrInvalidKeyText,
+		},
+		{
+			name:    "SecretKey",
+			ctx:     context.Background(),
+			keyText: string([]byte{0xc5, 0x01, 0x04}),
+			wantErr: ErrInvalidKeyText,
+		},
+		{
+			name:    "SecretSubkey",
+			ctx:     context.Background(),
+			keyText: string([]byte{0xc6, 0x01, 0x04, 0xc7, 0x01, 0x04}),
+			wantErr: ErrInvalidKeyText,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := MockPKSAddBatch{t: t, rejected: tt.rejected, throttled: tt.throttled, unavailable: tt.unavailable}
+
+			s := httptest.NewServer(&m)
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			r, err := c.PKSAddBatch(tt.ctx, tt.keyText, tt.opts)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if r != nil {
+				if got, want := batchFingerprints(r.Accepted), tt.wantAccepted; !reflect.DeepEqual(got, want) {
+					t.Errorf("got accepted %v, want %v", got, want)
+				}
+
+				if got, want := batchFingerprints(r.Rejected), tt.wantRejected; !reflect.DeepEqual(got, want) {
+					t.Errorf("got rejected %v, want %v", got, want)
+				}
+				for _, item := range r.Rejected {
+					if got, want := item.Err, (&HTTPError{code: http.StatusBadRequest}); !errors.Is(got, want) {
+						t.Errorf("got error %v, want %v", got, want)
+					}
+				}
+
+				if got, want := batchFingerprints(r.Resumed), tt.wantResumed; !reflect.DeepEqual(got, want) {
+					t.Errorf("got resumed %v, want %v", got, want)
+				}
+
+				if got, want := batchFingerprints(r.Skipped), tt.wantSkipped; !reflect.DeepEqual(got, want) {
+					t.Errorf("got skipped %v, want %v", got, want)
+				}
+				for _, item := range r.Skipped {
+					if got, want := item.Err, tt.wantSkipErr; want != nil && !errors.Is(got, want) {
+						t.Errorf("got error %v, want %v", got, want)
+					}
+				}
+
+				if got, want := r.ResumeToken, tt.wantResume; got != want {
+					t.Errorf("got resume token %q, want %q", got, want)
+				}
+			}
+
+			if got, want := m.requests, tt.wantRequests; got != want {
+				t.Errorf("got %v requests, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrInvalidArmor is returned when ASCII armored text cannot be decoded.
+var ErrInvalidArmor = errors.New("invalid armor")
+
+// Armor returns the binary OpenPGP packets in data encoded as an ASCII armored public key block.
+func Armor(data []byte) (string, error) {
+	return armor(data, armorTypePublicKey)
+}
+
+// Dearmor decodes all ASCII armored blocks in text, and returns the concatenation of their binary
+// contents. Text outside of armored blocks is ignored. If text contains no armored blocks, or a
+// block cannot be decoded, an error wrapping ErrInvalidArmor is returned.
+func Dearmor(text string) ([]byte, error) {
+	b, err := dearmor(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArmor, err)
+	}
+	return b, nil
+}
+
+// NewArmorWriter returns a writer that encodes binary OpenPGP packets written to it as an ASCII
+// armored public key block to w. The caller must call Close to flush the checksum and footer.
+func NewArmorWriter(w io.Writer) (io.WriteCloser, error) {
+	return newArmorWriter(w, armorTypePublicKey)
+}
+
+// isArmored returns true if b appears to contain ASCII armored data rather than binary packets.
+func isArmored(b []byte) bool {
+	b = bytes.TrimLeft(b, " \t\r\n")
+	return len(b) > 0 && b[0]&0x80 == 0
+}
+
+// GetKeyBinary retrieves a keyring matching search from the Key Service, and returns it as binary
+// OpenPGP packets. The search is interpreted as described by GetKey. Machine readable output is
+// requested, and responses are accepted in either binary or ASCII armored form. The context
+// controls the lifetime of the request.
+//
+// If the response contains neither binary packets nor an armored block, an error wrapping
+// ErrInvalidArmor is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) GetKeyBinary(ctx context.Context, search []byte) ([]byte, error) {
+	if !validSearch(search) {
+		return nil, fmt.Errorf("%w", ErrInvalidSearch)
+	}
+
+	body, err := c.Lookup(ctx, LookupRequest{
+		Search:    fmt.Sprintf("%#x", search),
+		Operation: OperationGet,
+		Options:   []LookupOption{OptionMachineReadable},
+		Exact:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if b := []byte(body); !isArmored(b) {
+		return b, nil
+	}
+	return Dearmor(body)
+}
+
+// contentTypePGPKeys is the media type of binary or ASCII armored OpenPGP keys (RFC 3156).
+const contentTypePGPKeys = "application/pgp-keys"
+
+// OptArmoredUploads sets whether PKSAddBinary always submits ASCII armored key text, rather than
+// first attempting a binary upload. This avoids a rejected request when the Key Service is known
+// not to accept binary uploads.
+func OptArmoredUploads(armored bool) Option {
+	return func(co *clientOptions) error {
+		co.armoredUploads = armored
+		return nil
+	}
+}
+
+// useArmoredUploads returns true if PKSAddBinary should submit ASCII armored key text.
+func (c *Client) useArmoredUploads() bool {
+	c.armorMu.Lock()
+	defer c.armorMu.Unlock()
+
+	return c.armoredUploads
+}
+
+// requireArmoredUploads records that the Key Service does not accept binary uploads.
+func (c *Client) requireArmoredUploads() {
+	c.armorMu.Lock()
+	defer c.armorMu.Unlock()
+
+	c.armoredUploads = true
+}
+
+// rejectsBinary returns true if err indicates the Key Service does not accept binary uploads.
+func rejectsBinary(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code() == http.StatusUnsupportedMediaType
+}
+
+// maxBinaryReplaySize is the maximum size of a keyring read from a reader that cannot seek that is
+// buffered, so that it can be submitted again as ASCII armored key text.
+const maxBinaryReplaySize = 1 << 20
+
+// PKSAddBinary submits the binary OpenPGP packets read from r to the Key Service. The context
+// controls the lifetime of the request.
+//
+// The packets are first submitted as-is, with media type "application/pgp-keys". If the Key
+// Service rejects the upload with status 415 (Unsupported Media Type), the packets are submitted
+// again as ASCII armored key text, as specified in section 4 of the OpenPGP HTTP Keyserver Protocol
+// (HKP) specification, and subsequent calls submit armored key text directly. To always submit
+// armored key text, use OptArmoredUploads.
+//
+// If r implements io.Seeker, packets are sent as they are read, and r is rewound if the packets
+// must be submitted again. Otherwise, keyrings of up to 1 MiB are buffered in memory, and larger
+// keyrings are submitted as armored key text, which is accepted by all key servers, without first
+// attempting a binary upload.
+//
+// If r contains no data, an error wrapping ErrInvalidKeyText is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) PKSAddBinary(ctx context.Context, r io.Reader) error {
+	if c.useArmoredUploads() {
+		return c.addArmored(ctx, r)
+	}
+
+	// Record the position of r, so that the packets can be read again if the upload is rejected.
+	var offset int64
+	s, seekable := r.(io.Seeker)
+	if seekable {
+		var err error
+		if offset, err = s.Seek(0, io.SeekCurrent); err != nil {
+			seekable = false
+		}
+	}
+
+	if !seekable {
+		b, err := io.ReadAll(io.LimitReader(r, maxBinaryReplaySize+1))
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		if len(b) > maxBinaryReplaySize {
+			return c.addArmored(ctx, io.MultiReader(bytes.NewReader(b), r))
+		}
+
+		br := bytes.NewReader(b)
+		r, s, offset = br, br, 0
+	}
+
+	err := c.addBinary(ctx, r)
+	if !rejectsBinary(err) {
+		return err
+	}
+
+	if _, err := s.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if err := c.addArmored(ctx, r); err != nil {
+		return err
+	}
+	c.requireArmoredUploads()
+	return nil
+}
+
+// addBinary submits the binary OpenPGP packets read from r to the Key Service.
+func (c *Client) addBinary(ctx context.Context, r io.Reader) error {
+	br, err := nonEmptyReader(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.postAdd(ctx, contentTypePGPKeys, br)
+	return err
+}
+
+// addArmored submits the binary OpenPGP packets read from r to the Key Service as ASCII armored key
+// text. Packets are armored and form-encoded as they are read.
+func (c *Client) addArmored(ctx context.Context, r io.Reader) error {
+	br, err := nonEmptyReader(r)
+	if err != nil {
+		return err
+	}
+
+	body := pipeBody(func(w io.Writer) error {
+		if _, err := io.WriteString(w, "keytext="); err != nil {
+			return err
+		}
+
+		aw, err := NewArmorWriter(&queryEscapeWriter{w: w})
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(aw, br); err != nil {
+			return err
+		}
+		return aw.Close()
+	})
+
+	_, err = c.postAdd(ctx, contentTypeForm, body)
+	return err
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestArmorExported(t *testing.T) {
+	want, err := dearmor(readTestKey(t, "alice.asc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := Armor(want)
+	if err != nil {
+		t.Fatalf("failed to armor: %v", err)
+	}
+
+	var sb strings.Builder
+	w, err := NewArmorWriter(&sb)
+	if err != nil {
+		t.Fatalf("failed to create armor writer: %v", err)
+	}
+	if _, err := w.Write(want); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+
+	if got := sb.String(); got != text {
+		t.Errorf("got armor writer output %q, want %q", got, text)
+	}
+
+	got, err := Dearmor(text)
+	if err != nil {
+		t.Fatalf("failed to dearmor: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("got unexpected dearmored data")
+	}
+
+	if _, err := Dearmor("blah"); !errors.Is(err, ErrInvalidArmor) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidArmor)
+	}
+}
+
+func TestGetKeyBinary(t *testing.T) {
+	alice := readTestKey(t, "alice.asc")
+
+	aliceBinary, err := dearmor(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	search := mustDecodeHex(t, aliceFingerprint)
+
+	tests := []struct {
+		name     string
+		code     int
+		search   []byte
+		response string
+		wantErr  error
+	}{
+		{
+			name:     "Armored",
+			code:     http.StatusOK,
+			search:   search,
+			response: alice,
+		},
+		{
+			name:     "Binary",
+			code:     http.StatusOK,
+			search:   search,
+			response: string(aliceBinary),
+		},
+		{
+			name:     "InvalidArmor",
+			code:     http.StatusOK,
+			search:   search,
+			response: "<html>No keys found</html>",
+			wantErr:  ErrInvalidArmor,
+		},
+		{
+			name:    "HTTPError",
+			code:    http.StatusNotFound,
+			search:  search,
+			wantErr: &HTTPError{code: http.StatusNotFound},
+		},
+		{
+			name:    "InvalidSearch",
+			search:  search[:1],
+			wantErr: ErrInvalidSearch,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockPKSLookup{
+				t:        t,
+				response: tt.response,
+				code:     tt.code,
+				search:   "0x" + strings.ToLower(aliceFingerprint),
+				op:       OperationGet,
+				options:  OptionMachineReadable,
+				exact:    true,
+			})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			b, err := c.GetKeyBinary(context.Background(), tt.search)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil && !bytes.Equal(b, aliceBinary) {
+				t.Error("got unexpected key")
+			}
+		})
+	}
+}
+
+// MockPKSAddBinary accepts keys submitted as binary packets or ASCII armored key text.
+type MockPKSAddBinary struct {
+	t          *testing.T
+	data       []byte // Expected binary packets.
+	acceptsBin bool   // Whether binary uploads are accepted.
+	code       int    // Status code for uploads that are accepted.
+
+	mu     sync.Mutex
+	binary int // Number of binary uploads received.
+	armor  int // Number of armored uploads received.
+}
+
+func (m *MockPKSAddBinary) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.URL.Path, pathPKSAdd; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	var data []byte
+
+	switch ct := r.Header.Get("Content-Type"); ct {
+	case contentTypePGPKeys:
+		m.mu.Lock()
+		m.binary++
+		m.mu.Unlock()
+
+		if !m.acceptsBin {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			m.t.Fatalf("failed to read body: %v", err)
+		}
+		data = b
+
+	case contentTypeForm:
+		m.mu.Lock()
+		m.armor++
+		m.mu.Unlock()
+
+		if err := r.ParseForm(); err != nil {
+			m.t.Fatalf("failed to parse form: %v", err)
+		}
+
+		b, err := dearmor(r.Form.Get("keytext"))
+		if err != nil {
+			m.t.Errorf("failed to dearmor key text: %v", err)
+		}
+		data = b
+
+	default:
+		m.t.Errorf("unexpected content type %v", ct)
+	}
+
+	if !bytes.Equal(data, m.data) {
+		m.t.Error("got unexpected key data")
+	}
+	w.WriteHeader(m.code)
+}
+
+func TestPKSAddBinary(t *testing.T) {
+	data, err := dearmor(readTestKey(t, "alice.asc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A keyring too large to be buffered.
+	large := bytes.Repeat(data, maxBinaryReplaySize/len(data)+1)
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		data       []byte
+		acceptsBin bool
+		code       int
+		r          func() io.Reader
+		wantErr    error
+		wantBinary int
+		wantArmor  int
+	}{
+		{
+			name:       "Binary",
+			acceptsBin: true,
+			code:       http.StatusOK,
+			r:          func() io.Reader { return bytes.NewReader(data) },
+			wantBinary: 2,
+		},
+		{
+			name:       "BinaryRejected",
+			code:       http.StatusOK,
+			r:          func() io.Reader { return bytes.NewReader(data) },
+			wantBinary: 1,
+			wantArmor:  2,
+		},
+		{
+			name:       "BinaryRejectedNotSeekable",
+			code:       http.StatusOK,
+			r:          func() io.Reader { return io.MultiReader(bytes.NewReader(data)) },
+			wantBinary: 1,
+			wantArmor:  2,
+		},
+		{
+			name:      "LargeNotSeekable",
+			data:      large,
+			code:      http.StatusOK,
+			r:         func() io.Reader { return io.MultiReader(bytes.NewReader(large)) },
+			wantArmor: 2,
+		},
+		{
+			name:      "ArmoredUploads",
+			opts:      []Option{OptArmoredUploads(true)},
+			code:      http.StatusOK,
+			r:         func() io.Reader { return io.MultiReader(bytes.NewReader(data)) },
+			wantArmor: 2,
+		},
+		{
+			name:       "HTTPError",
+			acceptsBin: true,
+			code:       http.StatusBadRequest,
+			r:          func() io.Reader { return bytes.NewReader(data) },
+			wantErr:    &HTTPError{code: http.StatusBadRequest},
+			wantBinary: 2,
+		},
+		{
+			name:    "Empty",
+			code:    http.StatusOK,
+			r:       func() io.Reader { return bytes.NewReader(nil) },
+			wantErr: ErrInvalidKeyText,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := tt.data
+			if want == nil {
+				want = data
+			}
+
+			m := &MockPKSAddBinary{t: t, data: want, acceptsBin: tt.acceptsBin, code: tt.code}
+
+			s := httptest.NewServer(m)
+			defer s.Close()
+
+			c, err := NewClient(append([]Option{OptBaseURL(s.URL)}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Upload twice, to verify a rejected binary upload is not repeated.
+			for i := 0; i < 2; i++ {
+				err = c.PKSAddBinary(context.Background(), tt.r())
+
+				if got, want := err, tt.wantErr; !errors.Is(got, want) {
+					t.Fatalf("got error %v, want %v", got, want)
+				}
+			}
+
+			if got, want := m.binary, tt.wantBinary; got != want {
+				t.Errorf("got %v binary uploads, want %v", got, want)
+			}
+			if got, want := m.armor, tt.wantArmor; got != want {
+				t.Errorf("got %v armored uploads, want %v", got, want)
+			}
+		})
+	}
+}
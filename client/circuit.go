@@ -0,0 +1,270 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when a request is not sent because the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitSuccessThreshold = 1
+	defaultCircuitOpenTimeout      = 30 * time.Second
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed indicates requests are sent to the Key Service.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen indicates requests fail without being sent to the Key Service.
+	CircuitOpen
+	// CircuitHalfOpen indicates trial requests are sent to the Key Service, to determine whether
+	// it has recovered.
+	CircuitHalfOpen
+)
+
+// String returns a string representation of s.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerPolicy describes when the circuit breaker opens and closes.
+type CircuitBreakerPolicy struct {
+	// Number of consecutive failed requests that cause the circuit to open (defaults to 5 if
+	// zero).
+	FailureThreshold int
+	// Duration the circuit remains open before trial requests are permitted (defaults to 30s if
+	// zero).
+	OpenTimeout time.Duration
+	// Number of consecutive successful trial requests that cause the circuit to close (defaults to
+	// 1 if zero).
+	SuccessThreshold int
+	// Function called when the state of the circuit changes (optional). It is called
+	// synchronously, from the goroutine making the request that caused the change.
+	OnStateChange func(from, to CircuitState)
+}
+
+// OptCircuitBreaker enables a circuit breaker, which stops requests being sent to the Key Service
+// while it is unhealthy.
+//
+// A request fails when a network error occurs, the request times out, or the Key Service responds
+// with a 5xx status code. Timeouts include those configured with OptTimeouts, and the deadline of
+// the caller's context. Requests abandoned because the caller's context was cancelled are
+// disregarded. Once
+// the number of consecutive failures reaches the failure threshold, the circuit opens, and requests
+// fail immediately with an error wrapping ErrCircuitOpen. After the open timeout, the circuit is
+// half-open, and trial requests are permitted one at a time. If the success threshold is reached,
+// the circuit closes, and if a trial request fails, the circuit opens again.
+//
+// Each request attempt, including retries, is subject to the circuit breaker.
+func OptCircuitBreaker(p CircuitBreakerPolicy) Option {
+	return func(co *clientOptions) error {
+		if p.FailureThreshold <= 0 {
+			p.FailureThreshold = defaultCircuitFailureThreshold
+		}
+		if p.OpenTimeout <= 0 {
+			p.OpenTimeout = defaultCircuitOpenTimeout
+		}
+		if p.SuccessThreshold <= 0 {
+			p.SuccessThreshold = defaultCircuitSuccessThreshold
+		}
+		co.circuitBreaker = &p
+		return nil
+	}
+}
+
+// circuitOutcome describes the effect of a request on the circuit breaker.
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	circuitIgnored
+)
+
+// circuitOutcomeOf returns the effect on the circuit breaker of the response to req.
+func circuitOutcomeOf(req *http.Request, res *http.Response, err error) circuitOutcome {
+	if err != nil {
+		// A request abandoned by the caller says nothing about the health of the Key Service, but a
+		// request that times out, including by exceeding the caller's deadline, indicates it is not
+		// responding.
+		if errors.Is(err, context.Canceled) || errors.Is(req.Context().Err(), context.Canceled) {
+			return circuitIgnored
+		}
+		return circuitFailure
+	}
+	if res.StatusCode >= http.StatusInternalServerError {
+		return circuitFailure
+	}
+	return circuitSuccess
+}
+
+// circuitTransition describes a change of circuit state.
+type circuitTransition struct {
+	from, to CircuitState
+}
+
+// circuitBreaker implements the circuit breaker state machine.
+type circuitBreaker struct {
+	policy CircuitBreakerPolicy
+
+	mu         sync.Mutex
+	state      CircuitState
+	generation uint64    // Incremented on each state change.
+	failures   int       // Consecutive failures while closed.
+	successes  int       // Consecutive successful trials while half-open.
+	trial      bool      // Whether a trial request is in flight while half-open.
+	openedAt   time.Time // Time the circuit last opened.
+}
+
+// newCircuitBreaker returns a closed circuit breaker with policy p.
+func newCircuitBreaker(p CircuitBreakerPolicy) *circuitBreaker {
+	return &circuitBreaker{policy: p}
+}
+
+// setState changes the state of b to s, and returns the resulting transitions. The caller must hold
+// b.mu.
+func (b *circuitBreaker) setState(s CircuitState, now time.Time) []circuitTransition {
+	t := circuitTransition{from: b.state, to: s}
+
+	b.state = s
+	b.generation++
+	b.failures = 0
+	b.successes = 0
+	b.trial = false
+	if s == CircuitOpen {
+		b.openedAt = now
+	}
+	return []circuitTransition{t}
+}
+
+// currentState returns the state of b.
+func (b *circuitBreaker) currentState() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// allow returns the generation in which a request is permitted, or an error wrapping
+// ErrCircuitOpen if it is not.
+func (b *circuitBreaker) allow(now time.Time) (uint64, []circuitTransition, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var ts []circuitTransition
+
+	if b.state == CircuitOpen {
+		remaining := b.openedAt.Add(b.policy.OpenTimeout).Sub(now)
+		if remaining > 0 {
+			return 0, nil, fmt.Errorf("%w: retry in %v", ErrCircuitOpen, remaining.Round(time.Millisecond))
+		}
+		ts = b.setState(CircuitHalfOpen, now)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.trial {
+			return 0, ts, fmt.Errorf("%w: trial request in progress", ErrCircuitOpen)
+		}
+		b.trial = true
+	}
+
+	return b.generation, ts, nil
+}
+
+// done records the outcome of a request permitted in generation gen.
+func (b *circuitBreaker) done(now time.Time, gen uint64, outcome circuitOutcome) []circuitTransition {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Disregard requests permitted before the last state change.
+	if gen != b.generation {
+		return nil
+	}
+
+	switch b.state {
+	case CircuitClosed:
+		switch outcome {
+		case circuitSuccess:
+			b.failures = 0
+		case circuitFailure:
+			if b.failures++; b.failures >= b.policy.FailureThreshold {
+				return b.setState(CircuitOpen, now)
+			}
+		}
+
+	case CircuitHalfOpen:
+		b.trial = false
+
+		switch outcome {
+		case circuitSuccess:
+			if b.successes++; b.successes >= b.policy.SuccessThreshold {
+				return b.setState(CircuitClosed, now)
+			}
+		case circuitFailure:
+			return b.setState(CircuitOpen, now)
+		}
+	}
+
+	return nil
+}
+
+// CircuitState returns the state of the circuit breaker. If the circuit breaker is not enabled,
+// CircuitClosed is returned.
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.currentState()
+}
+
+// notifyCircuitTransitions logs each transition, and reports it to the state change function.
+func (c *Client) notifyCircuitTransitions(ctx context.Context, ts []circuitTransition) {
+	for _, t := range ts {
+		c.logCircuitStateChange(ctx, t.from, t.to)
+
+		if fn := c.breaker.policy.OnStateChange; fn != nil {
+			fn(t.from, t.to)
+		}
+	}
+}
+
+// circuitAllow returns a function to record the outcome of req, or an error wrapping
+// ErrCircuitOpen if req may not be sent.
+func (c *Client) circuitAllow(req *http.Request) (func(circuitOutcome), error) {
+	if c.breaker == nil {
+		return func(circuitOutcome) {}, nil
+	}
+
+	gen, ts, err := c.breaker.allow(time.Now())
+	c.notifyCircuitTransitions(req.Context(), ts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(outcome circuitOutcome) {
+		ts := c.breaker.done(time.Now(), gen, outcome)
+		c.notifyCircuitTransitions(req.Context(), ts)
+	}, nil
+}
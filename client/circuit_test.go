@@ -0,0 +1,344 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitStateString(t *testing.T) {
+	tests := []struct {
+		state CircuitState
+		want  string
+	}{
+		{CircuitClosed, "closed"},
+		{CircuitOpen, "open"},
+		{CircuitHalfOpen, "half-open"},
+		{CircuitState(42), "CircuitState(42)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got, want := tt.state.String(), tt.want; got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCircuitOutcome(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context //nolint:containedctx
+		code int
+		err  error
+		want circuitOutcome
+	}{
+		{"OK", context.Background(), http.StatusOK, nil, circuitSuccess},
+		{"NotFound", context.Background(), http.StatusNotFound, nil, circuitSuccess},
+		{"TooManyRequests", context.Background(), http.StatusTooManyRequests, nil, circuitSuccess},
+		{"InternalServerError", context.Background(), http.StatusInternalServerError, nil, circuitFailure},
+		{"ServiceUnavailable", context.Background(), http.StatusServiceUnavailable, nil, circuitFailure},
+		{"NetworkError", context.Background(), 0, errors.New("connection refused"), circuitFailure},
+		{"ContextCanceled", cancelled, 0, context.Canceled, circuitIgnored},
+		{"DeadlineExceeded", expired, 0, context.DeadlineExceeded, circuitFailure},
+		{"Timeout", context.Background(), 0, &TimeoutError{Phase: TimeoutFirstByte, Duration: time.Second}, circuitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(tt.ctx)
+
+			var res *http.Response
+			if tt.err == nil {
+				res = &http.Response{StatusCode: tt.code}
+			}
+
+			if got, want := circuitOutcomeOf(req, res, tt.err), tt.want; got != want {
+				t.Errorf("got outcome %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	t0 := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	b := newCircuitBreaker(CircuitBreakerPolicy{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Second,
+		SuccessThreshold: 2,
+	})
+
+	var transitions []circuitTransition
+
+	allow := func(now time.Time) (uint64, error) {
+		gen, ts, err := b.allow(now)
+		transitions = append(transitions, ts...)
+		return gen, err
+	}
+	done := func(now time.Time, gen uint64, outcome circuitOutcome) {
+		transitions = append(transitions, b.done(now, gen, outcome)...)
+	}
+
+	// A success resets the consecutive failure count.
+	for _, outcome := range []circuitOutcome{circuitFailure, circuitSuccess, circuitFailure, circuitIgnored} {
+		gen, err := allow(t0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		done(t0, gen, outcome)
+	}
+	if got, want := b.currentState(), CircuitClosed; got != want {
+		t.Fatalf("got state %v, want %v", got, want)
+	}
+
+	// Consecutive failures open the circuit. The outcome of a request permitted before the circuit
+	// opened is disregarded.
+	stale, _ := allow(t0)
+	gen, _ := allow(t0)
+	done(t0, gen, circuitFailure)
+	done(t0, stale, circuitSuccess)
+	if got, want := b.currentState(), CircuitOpen; got != want {
+		t.Fatalf("got state %v, want %v", got, want)
+	}
+
+	if _, err := allow(t0.Add(500 * time.Millisecond)); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v", err, ErrCircuitOpen)
+	}
+
+	// After the open timeout, a single trial is permitted, which reopens the circuit if it fails.
+	t1 := t0.Add(time.Second)
+	gen, err := allow(t1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := allow(t1); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v", err, ErrCircuitOpen)
+	}
+	done(t1, gen, circuitFailure)
+	if got, want := b.currentState(), CircuitOpen; got != want {
+		t.Fatalf("got state %v, want %v", got, want)
+	}
+
+	// An ignored trial permits another trial, and successful trials close the circuit.
+	t2 := t1.Add(time.Second)
+	for _, outcome := range []circuitOutcome{circuitIgnored, circuitSuccess, circuitSuccess} {
+		gen, err := allow(t2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		done(t2, gen, outcome)
+	}
+	if got, want := b.currentState(), CircuitClosed; got != want {
+		t.Fatalf("got state %v, want %v", got, want)
+	}
+
+	want := []circuitTransition{
+		{CircuitClosed, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitClosed},
+	}
+	if got := transitions; !reflect.DeepEqual(got, want) {
+		t.Errorf("got transitions %v, want %v", got, want)
+	}
+}
+
+type MockToggle struct {
+	failing  atomic.Bool
+	requests atomic.Int32
+}
+
+func (m *MockToggle) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	m.requests.Add(1)
+
+	if m.failing.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write([]byte("key"))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	m := &MockToggle{}
+	m.failing.Store(true)
+
+	s := httptest.NewServer(m)
+	defer s.Close()
+
+	var (
+		mu     sync.Mutex
+		events []circuitTransition
+	)
+
+	c, err := NewClient(
+		OptBaseURL(s.URL),
+		OptRetry(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		OptCircuitBreaker(CircuitBreakerPolicy{
+			FailureThreshold: 2,
+			OpenTimeout:      50 * time.Millisecond,
+			OnStateChange: func(from, to CircuitState) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				events = append(events, circuitTransition{from, to})
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	lookup := func() error {
+		_, err := c.PKSLookup(context.Background(), nil, "search", OperationGet, false, false, nil)
+		return err
+	}
+
+	// The circuit opens during retries, so the final attempt is not sent.
+	if got, want := lookup(), ErrCircuitOpen; !errors.Is(got, want) {
+		t.Fatalf("got error %v, want %v", got, want)
+	}
+	if got, want := m.requests.Load(), int32(2); got != want {
+		t.Errorf("got %v requests, want %v", got, want)
+	}
+	if got, want := c.CircuitState(), CircuitOpen; got != want {
+		t.Errorf("got state %v, want %v", got, want)
+	}
+
+	// Requests fail fast while the circuit is open.
+	if got, want := lookup(), ErrCircuitOpen; !errors.Is(got, want) {
+		t.Fatalf("got error %v, want %v", got, want)
+	}
+	if got, want := m.requests.Load(), int32(2); got != want {
+		t.Errorf("got %v requests, want %v", got, want)
+	}
+
+	// A failed trial reopens the circuit.
+	time.Sleep(60 * time.Millisecond)
+	if got, want := lookup(), ErrCircuitOpen; !errors.Is(got, want) {
+		t.Fatalf("got error %v, want %v", got, want)
+	}
+	if got, want := m.requests.Load(), int32(3); got != want {
+		t.Errorf("got %v requests, want %v", got, want)
+	}
+
+	// A successful trial closes the circuit once the service recovers.
+	m.failing.Store(false)
+	time.Sleep(60 * time.Millisecond)
+	if err := lookup(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := c.CircuitState(), CircuitClosed; got != want {
+		t.Errorf("got state %v, want %v", got, want)
+	}
+	if err := lookup(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := m.requests.Load(), int32(5); got != want {
+		t.Errorf("got %v requests, want %v", got, want)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := []circuitTransition{
+		{CircuitClosed, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitClosed},
+	}
+	if got := events; !reflect.DeepEqual(got, want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
+
+func TestCircuitBreakerStalled(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		deadline time.Duration
+	}{
+		{"CallerDeadline", nil, 50 * time.Millisecond},
+		{"FirstByteTimeout", []Option{OptTimeouts(Timeouts{FirstByte: 50 * time.Millisecond})}, 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The server never responds.
+			s := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			}))
+			defer s.Close()
+
+			opts := append([]Option{
+				OptBaseURL(s.URL),
+				OptRetry(RetryPolicy{MaxAttempts: 1}),
+				OptCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 2}),
+			}, tt.opts...)
+
+			c, err := NewClient(opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			getKey := func() error {
+				ctx := context.Background()
+				if tt.deadline > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, tt.deadline)
+					defer cancel()
+				}
+
+				_, err := c.GetKey(ctx, mustDecodeHex(t, aliceFingerprint))
+				return err
+			}
+
+			for i := 0; i < 2; i++ {
+				if got, want := getKey(), context.DeadlineExceeded; !errors.Is(got, want) {
+					t.Fatalf("got error %v, want %v", got, want)
+				}
+			}
+
+			if got, want := c.CircuitState(), CircuitOpen; got != want {
+				t.Errorf("got state %v, want %v", got, want)
+			}
+			if got, want := getKey(), ErrCircuitOpen; !errors.Is(got, want) {
+				t.Errorf("got error %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	c, err := NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if got, want := c.CircuitState(), CircuitClosed; got != want {
+		t.Errorf("got state %v, want %v", got, want)
+	}
+}
@@ -10,10 +10,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -68,6 +73,21 @@ type clientOptions struct {
 	bearerToken string
 	userAgent   string
 	httpClient  *http.Client
+	dedup       bool
+
+	acceptEncodings []string
+	compressUploads bool
+	metrics         Metrics
+	middleware      []Middleware
+	retry           RetryPolicy
+	tracerProvider  trace.TracerProvider
+	logger          *slog.Logger
+	errorDecoders   []ErrorDecoder
+	rateLimit       *rateLimit
+	uploadRateLimit *rateLimit
+	circuitBreaker  *CircuitBreakerPolicy
+	timeouts        Timeouts
+	armoredUploads  bool
 }
 
 // Option are used to populate co.
@@ -106,14 +126,57 @@ func OptHTTPClient(c *http.Client) Option {
 	}
 }
 
+// OptDeduplicateLookups sets whether concurrent, identical lookup requests are collapsed into a
+// single HTTP request, the result of which is shared between callers. Lookup requests are
+// identical when their search, operation, options and pagination details match.
+//
+// Cancellation of the context passed by a caller does not abort a shared request while other
+// callers are still waiting on the result.
+func OptDeduplicateLookups(dedup bool) Option {
+	return func(co *clientOptions) error {
+		co.dedup = dedup
+		return nil
+	}
+}
+
 const defaultBaseURL = "https://keys.sylabs.io/"
 
 // Client describes the client details.
 type Client struct {
-	baseURL     *url.URL     // Parsed base URL.
-	bearerToken string       // Bearer token to include in "Authorization" header.
-	userAgent   string       // Value to include in "User-Agent" header.
-	httpClient  *http.Client // Client to use for HTTP requests.
+	baseURL     *url.URL            // Parsed base URL.
+	bearerToken string              // Bearer token to include in "Authorization" header.
+	userAgent   string              // Value to include in "User-Agent" header.
+	httpClient  *http.Client        // Client to use for HTTP requests.
+	dedup       bool                // Whether to deduplicate identical lookup requests.
+	lookups     group[lookupResult] // In-flight lookup requests.
+
+	serverInfoMu    sync.Mutex         // Protects serverInfo.
+	serverInfo      *ServerInfo        // Cached server information.
+	serverInfoGroup group[*ServerInfo] // In-flight server information requests.
+
+	acceptEncodings []string   // Content codings to request, in order of preference.
+	compressUploads bool       // Whether to compress uploads.
+	encodingMu      sync.Mutex // Protects serverEncodings.
+	serverEncodings []string   // Content codings advertised by the key server.
+
+	metrics Metrics // Measurement hooks (optional).
+
+	transport http.RoundTripper // Middleware chain, terminating in httpClient.
+	retry     RetryPolicy       // Retry policy.
+	tracer    trace.Tracer      // Tracer used to record operations.
+	logger    *slog.Logger      // Logger (optional).
+
+	errorDecoders []ErrorDecoder // Additional error response decoders.
+
+	lookupLimiter *tokenBucket // Rate limiter for lookups (optional).
+	uploadLimiter *tokenBucket // Rate limiter for uploads (optional).
+
+	breaker *circuitBreaker // Circuit breaker (optional).
+
+	timeouts Timeouts // Default request timeouts.
+
+	armorMu        sync.Mutex // Protects armoredUploads.
+	armoredUploads bool       // Whether PKSAddBinary submits armored key text.
 }
 
 // NewClient returns a Client to interact with an HKP key server according to opts.
@@ -127,6 +190,7 @@ func NewClient(opts ...Option) (*Client, error) {
 	co := clientOptions{
 		baseURL:    defaultBaseURL,
 		httpClient: http.DefaultClient,
+		timeouts:   defaultTimeouts,
 	}
 
 	// Apply options.
@@ -140,7 +204,39 @@ func NewClient(opts ...Option) (*Client, error) {
 		bearerToken: co.bearerToken,
 		userAgent:   co.userAgent,
 		httpClient:  co.httpClient,
+		dedup:       co.dedup,
+
+		acceptEncodings: co.acceptEncodings,
+		compressUploads: co.compressUploads,
+		metrics:         co.metrics,
+		retry:           co.retry,
+		logger:          co.logger,
+		errorDecoders:   co.errorDecoders,
+		timeouts:        co.timeouts,
+		armoredUploads:  co.armoredUploads,
+	}
+
+	if rl := co.rateLimit; rl != nil {
+		c.lookupLimiter = newTokenBucket(*rl)
+		c.uploadLimiter = newTokenBucket(*rl)
+	}
+	if rl := co.uploadRateLimit; rl != nil {
+		c.uploadLimiter = newTokenBucket(*rl)
+	}
+
+	if p := co.circuitBreaker; p != nil {
+		c.breaker = newCircuitBreaker(*p)
+	}
+
+	// Terminate the middleware chain with the HTTP client, so its redirect, cookie and timeout
+	// handling apply to each attempt.
+	c.transport = chainMiddleware(RoundTripperFunc(c.httpClient.Do), co.middleware)
+
+	tp := co.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
 	}
+	c.tracer = tp.Tracer(tracerName)
 
 	// Normalize base URL.
 	u, err := normalizeURL(co.baseURL)
@@ -177,10 +273,36 @@ func (c *Client) NewRequest(ctx context.Context, method string, ref *url.URL, bo
 		r.Header.Set("User-Agent", v)
 	}
 
+	if len(c.acceptEncodings) > 0 {
+		r.Header.Set("Accept-Encoding", strings.Join(c.acceptEncodings, ", "))
+	}
+
 	return r, nil
 }
 
 // Do sends an HTTP request and returns an HTTP response.
+//
+// The request is passed through the middleware chain configured with OptMiddleware, and retried
+// according to the policy configured with OptRetry. If compression was requested using
+// OptCompression, the response body is decoded transparently. Default timeouts (see OptTimeouts)
+// apply until the response body is closed, and the total timeout covers this request only.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	return c.httpClient.Do(req)
+	req, cancel := c.withTotalTimeout(req)
+
+	res, err := c.doRetry(req)
+	if err != nil {
+		cancel()
+		return nil, timeoutError(req.Context(), err)
+	}
+
+	trace.SpanFromContext(req.Context()).SetAttributes(attrStatusCode.Int(res.StatusCode))
+
+	c.recordAcceptEncoding(res)
+
+	res.Body = &cancelBody{ReadCloser: res.Body, ctx: req.Context(), cancel: cancel}
+
+	if err := c.wrapResponseBody(req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
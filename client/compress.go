@@ -0,0 +1,254 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Content codings supported by the client.
+const (
+	EncodingGzip = "gzip"
+	EncodingZstd = "zstd"
+)
+
+// ErrUnsupportedEncoding is returned when a content coding is not supported.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// validEncoding returns an error wrapping ErrUnsupportedEncoding if e is not a supported content
+// coding.
+func validEncoding(e string) error {
+	switch e {
+	case EncodingGzip, EncodingZstd:
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrUnsupportedEncoding, e)
+}
+
+// OptCompression requests that the Key Service compress responses using one of the specified
+// content codings, in order of preference. Responses are decoded transparently. The supported
+// codings are EncodingGzip and EncodingZstd.
+func OptCompression(encodings ...string) Option {
+	return func(co *clientOptions) error {
+		for _, e := range encodings {
+			if err := validEncoding(e); err != nil {
+				return err
+			}
+		}
+		co.acceptEncodings = encodings
+		return nil
+	}
+}
+
+// OptCompressUploads sets whether key material submitted to the Key Service is compressed. Uploads
+// are only compressed once the Key Service has advertised support for a content coding supported
+// by the client, via the "Accept-Encoding" header of a response (RFC 7694). Codings are preferred
+// in the order specified by OptCompression, if set.
+func OptCompressUploads(compress bool) Option {
+	return func(co *clientOptions) error {
+		co.compressUploads = compress
+		return nil
+	}
+}
+
+// recordAcceptEncoding records the content codings advertised by the Key Service in res.
+func (c *Client) recordAcceptEncoding(res *http.Response) {
+	v := res.Header.Values("Accept-Encoding")
+	if len(v) == 0 {
+		return
+	}
+
+	var encodings []string
+	for _, s := range v {
+		for _, e := range strings.Split(s, ",") {
+			// Discard parameters such as quality values.
+			e, _, _ = strings.Cut(e, ";")
+			if e = strings.ToLower(strings.TrimSpace(e)); validEncoding(e) == nil {
+				encodings = append(encodings, e)
+			}
+		}
+	}
+
+	c.encodingMu.Lock()
+	defer c.encodingMu.Unlock()
+
+	c.serverEncodings = encodings
+}
+
+// uploadEncoding returns the content coding to use for uploads, or an empty string if uploads
+// should not be compressed.
+func (c *Client) uploadEncoding() string {
+	if !c.compressUploads {
+		return ""
+	}
+
+	c.encodingMu.Lock()
+	defer c.encodingMu.Unlock()
+
+	preferred := c.acceptEncodings
+	if len(preferred) == 0 {
+		preferred = []string{EncodingZstd, EncodingGzip}
+	}
+	for _, e := range preferred {
+		if slices.Contains(c.serverEncodings, e) {
+			return e
+		}
+	}
+	return ""
+}
+
+// compressBody returns a reader that yields body compressed with the content coding e. If body is
+// an io.Closer, it is closed once consumed.
+func compressBody(body io.Reader, e string) io.ReadCloser {
+	return pipeBody(func(w io.Writer) error {
+		if rc, ok := body.(io.Closer); ok {
+			defer rc.Close()
+		}
+
+		var cw io.WriteCloser
+
+		switch e {
+		case EncodingGzip:
+			cw = gzip.NewWriter(w)
+		case EncodingZstd:
+			zw, err := zstd.NewWriter(w)
+			if err != nil {
+				return err
+			}
+			cw = zw
+		default:
+			return validEncoding(e)
+		}
+
+		if _, err := io.Copy(cw, body); err != nil {
+			cw.Close()
+			return err
+		}
+		return cw.Close()
+	})
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read reads from the underlying reader.
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// responseBody decodes and measures a response body.
+type responseBody struct {
+	wire    *countingReader // Counts bytes read from the network.
+	decoded *countingReader // Counts bytes after decoding.
+	close   func() error    // Closes the decoder and underlying body.
+	done    func(wire, decoded int64)
+}
+
+// Read reads decoded data.
+func (rb *responseBody) Read(p []byte) (int, error) {
+	return rb.decoded.Read(p)
+}
+
+// Close closes the body, and reports the number of bytes read.
+func (rb *responseBody) Close() error {
+	err := rb.close()
+	if rb.done != nil {
+		rb.done(rb.wire.n, rb.decoded.n)
+		rb.done = nil
+	}
+	return err
+}
+
+// wrapResponseBody transparently decodes the body of res, the response to req, if it has been
+// compressed using a supported content coding. Bodies with the "identity" or an unsupported coding
+// are passed through unchanged, along with the "Content-Encoding" header. The size of the body is
+// reported to the span of the current operation and the metrics hooks when the body is closed.
+func (c *Client) wrapResponseBody(req *http.Request, res *http.Response) error {
+	body := res.Body
+	wire := &countingReader{r: body}
+
+	var decoder io.Reader = wire
+	closeFn := body.Close
+
+	encoding := strings.ToLower(res.Header.Get("Content-Encoding"))
+
+	if len(c.acceptEncodings) > 0 && encoding != "" {
+		decoded := true
+
+		switch encoding {
+		case EncodingGzip:
+			zr, err := gzip.NewReader(wire)
+			if err != nil && !errors.Is(err, io.EOF) {
+				body.Close()
+				return fmt.Errorf("%w", err)
+			}
+			if zr != nil {
+				decoder = zr
+			}
+		case EncodingZstd:
+			zr, err := zstd.NewReader(wire)
+			if err != nil {
+				body.Close()
+				return fmt.Errorf("%w", err)
+			}
+			decoder = zr
+			closeFn = func() error {
+				zr.Close()
+				return body.Close()
+			}
+		default:
+			decoded = false
+		}
+
+		if decoded {
+			res.Header.Del("Content-Encoding")
+			res.Header.Del("Content-Length")
+			res.ContentLength = -1
+			res.Uncompressed = true
+		}
+	}
+
+	ts := TransferStats{
+		Operation: operationFromContext(req.Context()),
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Encoding:  encoding,
+	}
+	uncompressedByTransport := res.Uncompressed && encoding == ""
+	span := trace.SpanFromContext(req.Context())
+
+	res.Body = &responseBody{
+		wire:    wire,
+		decoded: &countingReader{r: decoder},
+		close:   closeFn,
+		done: func(wire, decoded int64) {
+			span.SetAttributes(attrResponseLength.Int64(decoded))
+
+			if m := c.metrics; m != nil {
+				ts.WireBytes, ts.DecodedBytes = wire, decoded
+				if uncompressedByTransport {
+					ts.WireBytes = -1
+				}
+				m.ObserveTransfer(ts)
+			}
+		},
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compress returns b compressed with the content coding e.
+func compress(t *testing.T, b []byte, e string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	var w io.WriteCloser
+	switch e {
+	case EncodingGzip:
+		w = gzip.NewWriter(&buf)
+	case EncodingZstd:
+		zw, err := zstd.NewWriter(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w = zw
+	}
+
+	if _, err := w.Write(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// MockCompression serves compressed lookup responses, and accepts compressed uploads.
+type MockCompression struct {
+	t                *testing.T
+	response         string
+	advertise        string // Value of "Accept-Encoding" response header.
+	wantUploadCoding string // Expected "Content-Encoding" of uploads.
+	encoding         string // "Content-Encoding" of lookup responses, which are not compressed.
+
+	mu      sync.Mutex
+	keyText string // Key text received.
+}
+
+func (m *MockCompression) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.advertise != "" {
+		w.Header().Set("Accept-Encoding", m.advertise)
+	}
+
+	if r.URL.Path == pathPKSAdd {
+		if got, want := r.Header.Get("Content-Encoding"), m.wantUploadCoding; got != want {
+			m.t.Errorf("got content encoding %q, want %q", got, want)
+		}
+
+		var body io.Reader = r.Body
+		switch r.Header.Get("Content-Encoding") {
+		case EncodingGzip:
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				m.t.Fatalf("failed to create gzip reader: %v", err)
+			}
+			body = zr
+		case EncodingZstd:
+			zr, err := zstd.NewReader(r.Body)
+			if err != nil {
+				m.t.Fatalf("failed to create zstd reader: %v", err)
+			}
+			defer zr.Close()
+			body = zr
+		}
+
+		b, err := io.ReadAll(body)
+		if err != nil {
+			m.t.Fatalf("failed to read body: %v", err)
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(b))
+		if err := r.ParseForm(); err != nil {
+			m.t.Fatalf("failed to parse form: %v", err)
+		}
+
+		m.mu.Lock()
+		m.keyText = r.Form.Get("keytext")
+		m.mu.Unlock()
+		return
+	}
+
+	if m.encoding != "" {
+		w.Header().Set("Content-Encoding", m.encoding)
+		_, _ = io.WriteString(w, m.response)
+		return
+	}
+
+	// Use the first coding requested by the client.
+	e, _, _ := strings.Cut(r.Header.Get("Accept-Encoding"), ",")
+	switch e {
+	case EncodingGzip, EncodingZstd:
+		w.Header().Set("Content-Encoding", e)
+		_, _ = w.Write(compress(m.t, []byte(m.response), e))
+	default:
+		_, _ = io.WriteString(w, m.response)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	response := strings.Repeat("Not valid, but it'll do for testing. ", 100)
+
+	tests := []struct {
+		name         string
+		encodings    []string
+		wantEncoding string
+	}{
+		{"None", nil, ""},
+		{"Gzip", []string{EncodingGzip}, EncodingGzip},
+		{"Zstd", []string{EncodingZstd, EncodingGzip}, EncodingZstd},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockCompression{t: t, response: response})
+			defer s.Close()
+
+			var tr metricsRecorder
+
+			// Use a transport that does not transparently request gzip, so wire sizes are known.
+			c, err := NewClient(
+				OptBaseURL(s.URL),
+				OptHTTPClient(&http.Client{Transport: &http.Transport{DisableCompression: true}}),
+				OptCompression(tt.encodings...),
+				OptMetrics(&tr),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			got, err := c.PKSLookup(context.Background(), nil, "search", OperationGet, false, false, nil)
+			if err != nil {
+				t.Fatalf("failed to lookup: %v", err)
+			}
+
+			if got != response {
+				t.Errorf("got unexpected response")
+			}
+
+			if got, want := len(tr.transfers), 1; got != want {
+				t.Fatalf("got %v transfers, want %v", got, want)
+			}
+			ts := tr.transfers[0]
+
+			if got, want := ts.Encoding, tt.wantEncoding; got != want {
+				t.Errorf("got encoding %q, want %q", got, want)
+			}
+			if got, want := ts.DecodedBytes, int64(len(response)); got != want {
+				t.Errorf("got %v decoded bytes, want %v", got, want)
+			}
+			if tt.wantEncoding != "" {
+				if ts.WireBytes >= ts.DecodedBytes {
+					t.Errorf("got %v wire bytes, want fewer than %v", ts.WireBytes, ts.DecodedBytes)
+				}
+			} else if ts.WireBytes != ts.DecodedBytes {
+				t.Errorf("got %v wire bytes, want %v", ts.WireBytes, ts.DecodedBytes)
+			}
+			if got, want := ts.Path, pathPKSLookup; got != want {
+				t.Errorf("got path %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCompressionPassthrough(t *testing.T) {
+	const response = "key"
+
+	tests := []struct {
+		name     string
+		encoding string
+	}{
+		{"Identity", "identity"},
+		{"Unsupported", "br"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockCompression{t: t, response: response, encoding: tt.encoding})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL), OptCompression(EncodingGzip))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			got, err := c.PKSLookup(context.Background(), nil, "search", OperationGet, false, false, nil)
+			if err != nil {
+				t.Fatalf("failed to lookup: %v", err)
+			}
+
+			if want := response; got != want {
+				t.Errorf("got response %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCompressUploads(t *testing.T) {
+	keyText := readTestKey(t, "alice.asc")
+
+	tests := []struct {
+		name       string
+		encodings  []string
+		advertise  string
+		wantCoding string
+	}{
+		{"NotAdvertised", nil, "", ""},
+		{"Gzip", nil, "gzip", EncodingGzip},
+		{"PreferZstd", nil, "gzip, zstd;q=0.5", EncodingZstd},
+		{"Preference", []string{EncodingGzip, EncodingZstd}, "zstd, gzip", EncodingGzip},
+		{"Unsupported", nil, "br", ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := MockCompression{
+				t:                t,
+				response:         "{}",
+				advertise:        tt.advertise,
+				wantUploadCoding: tt.wantCoding,
+			}
+
+			s := httptest.NewServer(&m)
+			defer s.Close()
+
+			c, err := NewClient(
+				OptBaseURL(s.URL),
+				OptCompression(tt.encodings...),
+				OptCompressUploads(true),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// The first request discovers the codings supported by the server.
+			if _, err := c.PKSLookup(context.Background(), nil, "search", OperationGet, false, false, nil); err != nil {
+				t.Fatalf("failed to lookup: %v", err)
+			}
+
+			if err := c.PKSAdd(context.Background(), keyText); err != nil {
+				t.Fatalf("failed to add: %v", err)
+			}
+
+			if got, want := m.keyText, keyText; got != want {
+				t.Errorf("got key text %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestOptCompression(t *testing.T) {
+	if _, err := NewClient(OptCompression("br")); !errors.Is(err, ErrUnsupportedEncoding) {
+		t.Errorf("got error %v, want %v", err, ErrUnsupportedEncoding)
+	}
+}
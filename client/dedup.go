@@ -0,0 +1,96 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// call describes an in-flight or completed call shared between one or more callers.
+type call[T any] struct {
+	done    chan struct{}      // Closed when the call completes.
+	cancel  context.CancelFunc // Cancels the context passed to the call.
+	waiters int                // Number of callers waiting on the result.
+	val     T
+	err     error
+}
+
+// group collapses concurrent calls with the same key into a single execution. The zero value is
+// ready to use.
+type group[T any] struct {
+	mu sync.Mutex
+	m  map[string]*call[T]
+}
+
+// do executes fn, ensuring only one execution is in-flight for a given key at a time. If a
+// duplicate call is made while an execution is in-flight, the duplicate caller waits for the
+// original to complete and receives the same results, and shared is true.
+//
+// The context passed to fn carries the values of ctx, including any trace span, but not its
+// deadline, and is not cancelled when ctx is. Each caller is subject to its own context: if ctx is
+// cancelled or its deadline expires, do returns immediately with the context error. The context
+// passed to fn is cancelled only when every caller waiting on the result has returned in this way.
+func (g *group[T]) do(ctx context.Context, key string, fn func(context.Context) (T, error)) (v T, shared bool, err error) {
+	var zero T
+
+	if err := ctx.Err(); err != nil {
+		return zero, false, err
+	}
+
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[string]*call[T])
+	}
+	c, ok := g.m[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+		c = &call[T]{done: make(chan struct{}), cancel: cancel}
+		g.m[key] = c
+
+		go func() {
+			defer close(c.done)
+			defer cancel()
+
+			c.val, c.err = fn(callCtx)
+
+			g.forget(key, c)
+		}()
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, ok, c.err
+
+	case <-ctx.Done():
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		// If this was the last caller waiting on the result, abandon the call, and ensure subsequent
+		// callers do not join it.
+		if c.waiters--; c.waiters == 0 {
+			c.cancel()
+
+			if g.m[key] == c {
+				delete(g.m, key)
+			}
+		}
+		return zero, ok, ctx.Err()
+	}
+}
+
+// forget removes c from g, if it is still associated with key.
+func (g *group[T]) forget(key string, c *call[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.m[key] == c {
+		delete(g.m, key)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForWaiters blocks until the call associated with key in g has n waiters.
+func waitForWaiters[T any](t *testing.T, g *group[T], key string, n int) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		g.mu.Lock()
+		c, ok := g.m[key]
+		done := ok && c.waiters == n
+		g.mu.Unlock()
+
+		if done {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %v waiters", n)
+}
+
+func TestGroupDo(t *testing.T) {
+	const callers = 10
+
+	var g group[string]
+	var calls, shared atomic.Int32
+	release := make(chan struct{})
+
+	fn := func(context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "result", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			v, s, err := g.do(context.Background(), "key", fn)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if s {
+				shared.Add(1)
+			}
+			if got, want := v, "result"; got != want {
+				t.Errorf("got value %v, want %v", got, want)
+			}
+		}()
+	}
+
+	waitForWaiters(t, &g, "key", callers)
+	close(release)
+	wg.Wait()
+
+	if got, want := calls.Load(), int32(1); got != want {
+		t.Errorf("got %v calls, want %v", got, want)
+	}
+	if got, want := shared.Load(), int32(callers-1); got != want {
+		t.Errorf("got %v shared results, want %v", got, want)
+	}
+}
+
+func TestGroupDoCancel(t *testing.T) {
+	var g group[string]
+	release := make(chan struct{})
+	fnErr := make(chan error, 1)
+
+	fn := func(ctx context.Context) (string, error) {
+		select {
+		case <-release:
+			return "result", nil
+		case <-ctx.Done():
+			fnErr <- ctx.Err()
+			return "", ctx.Err()
+		}
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	errs := make(chan error, 2)
+	go func() {
+		_, _, err := g.do(ctx1, "key", fn)
+		errs <- err
+	}()
+	go func() {
+		_, _, err := g.do(ctx2, "key", fn)
+		errs <- err
+	}()
+	waitForWaiters(t, &g, "key", 2)
+
+	// Cancelling one caller must not abort the shared call.
+	cancel1()
+	if got, want := <-errs, context.Canceled; !errors.Is(got, want) {
+		t.Fatalf("got error %v, want %v", got, want)
+	}
+	waitForWaiters(t, &g, "key", 1)
+
+	select {
+	case err := <-fnErr:
+		t.Fatalf("shared call aborted: %v", err)
+	default:
+	}
+
+	// Cancelling the last caller aborts the shared call.
+	cancel2()
+	if got, want := <-errs, context.Canceled; !errors.Is(got, want) {
+		t.Fatalf("got error %v, want %v", got, want)
+	}
+	if got, want := <-fnErr, context.Canceled; !errors.Is(got, want) {
+		t.Fatalf("got call error %v, want %v", got, want)
+	}
+}
+
+func TestGroupDoDeadline(t *testing.T) {
+	var g group[string]
+	release := make(chan struct{})
+	fnErr := make(chan error, 1)
+
+	fn := func(ctx context.Context) (string, error) {
+		// The call is not subject to the deadline of the caller that started it.
+		if got, ok := ctx.Deadline(); ok {
+			t.Errorf("got deadline %v, want none", got)
+		}
+
+		select {
+		case <-release:
+			return "result", nil
+		case <-ctx.Done():
+			fnErr <- ctx.Err()
+			return "", ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(ctx, "key", fn)
+		errs <- err
+	}()
+	waitForWaiters(t, &g, "key", 1)
+
+	type result struct {
+		v   string
+		err error
+	}
+	results := make(chan result, 1)
+	go func() {
+		v, _, err := g.do(context.Background(), "key", fn)
+		results <- result{v, err}
+	}()
+	waitForWaiters(t, &g, "key", 2)
+
+	// The caller that started the call fails when its deadline expires.
+	if got, want := <-errs, context.DeadlineExceeded; !errors.Is(got, want) {
+		t.Fatalf("got error %v, want %v", got, want)
+	}
+	waitForWaiters(t, &g, "key", 1)
+
+	select {
+	case err := <-fnErr:
+		t.Fatalf("shared call aborted: %v", err)
+	default:
+	}
+
+	// The caller that joined the call receives the result.
+	close(release)
+	r := <-results
+	if r.err != nil {
+		t.Fatalf("unexpected error: %v", r.err)
+	}
+	if got, want := r.v, "result"; got != want {
+		t.Errorf("got value %v, want %v", got, want)
+	}
+}
+
+func TestPKSLookupDeduplication(t *testing.T) {
+	const callers = 10
+
+	tests := []struct {
+		name      string
+		dedup     bool
+		wantCalls int32
+	}{
+		{"Disabled", false, callers},
+		{"Enabled", true, 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			release := make(chan struct{})
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				<-release
+				w.Header().Set("X-HKP-Next-Page-Token", "bar")
+				_, _ = w.Write([]byte("response"))
+			}))
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL), OptDeduplicateLookups(tt.dedup))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					var pd PageDetails
+					r, err := c.PKSLookup(context.Background(), &pd, "search", OperationGet, false, false, nil)
+					if err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+					if got, want := r, "response"; got != want {
+						t.Errorf("got response %v, want %v", got, want)
+					}
+					if got, want := pd.Token, "bar"; got != want {
+						t.Errorf("got page token %v, want %v", got, want)
+					}
+				}()
+			}
+
+			// Wait for all requests to be in-flight before releasing the server.
+			for deadline := time.Now().Add(5 * time.Second); ; {
+				if calls.Load() == tt.wantCalls {
+					if !tt.dedup {
+						break
+					}
+
+					c.lookups.mu.Lock()
+					n := 0
+					for _, call := range c.lookups.m {
+						n = call.waiters
+					}
+					c.lookups.mu.Unlock()
+
+					if n == callers {
+						break
+					}
+				}
+				if time.Now().After(deadline) {
+					t.Fatal("timed out waiting for requests")
+				}
+				time.Sleep(time.Millisecond)
+			}
+			close(release)
+			wg.Wait()
+
+			if got, want := calls.Load(), tt.wantCalls; got != want {
+				t.Errorf("got %v calls, want %v", got, want)
+			}
+		})
+	}
+}
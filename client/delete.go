@@ -0,0 +1,93 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const pathPKSDelete = "/pks/delete"
+
+// ErrInvalidFingerprint is returned when a fingerprint is invalid.
+var ErrInvalidFingerprint = errors.New("invalid fingerprint")
+
+// ErrAuthRequired is returned when an operation requires a bearer token, and none was supplied.
+var ErrAuthRequired = errors.New("bearer token required")
+
+// DeleteKey requests that the key with the specified 160-bit version 4 or 256-bit version 6
+// fingerprint be removed from the Key Service. Deletion is authorized using the bearer token
+// supplied with OptBearerToken. The context controls the lifetime of the request.
+//
+// The key is deleted with a DELETE request to "/pks/delete", carrying the fingerprint in the
+// "search" parameter, as supported by the Sylabs Key Service. Hockeypuck instead accepts signed
+// POST requests at the same path, which are made by PKSDelete.
+//
+// If no bearer token was supplied, an error wrapping ErrAuthRequired is returned. If the Key
+// Service does not support deletion, an error wrapping ErrUnsupportedOperation is returned. Key
+// servers without deletion support commonly respond with status 404 (Not Found), so in that case
+// the key is looked up: if it exists, the error wraps ErrUnsupportedOperation, and if it does not,
+// the error wraps ErrKeyNotFound.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) DeleteKey(ctx context.Context, fingerprint []byte) (err error) {
+	ctx, op := c.startOperation(ctx, "DeleteKey")
+	defer func() { op.end(err) }()
+
+	switch len(fingerprint) {
+	case 20, 32:
+		break
+	default:
+		return fmt.Errorf("%w", ErrInvalidFingerprint)
+	}
+
+	if c.bearerToken == "" {
+		return fmt.Errorf("%w", ErrAuthRequired)
+	}
+
+	v := url.Values{}
+	v.Set("search", fmt.Sprintf("%#x", fingerprint))
+
+	ref := &url.URL{Path: pathPKSDelete, RawQuery: v.Encode()}
+
+	req, err := c.NewRequest(ctx, http.MethodDelete, ref, nil)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	res, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 { // non-2xx status code
+		err := c.operationErrorFromResponse(res)
+		if res.StatusCode == http.StatusNotFound {
+			return c.deleteNotFoundError(ctx, fingerprint, err)
+		}
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// deleteNotFoundError returns an error describing a 404 (Not Found) response to a request to delete
+// the key with the specified fingerprint. The key is looked up to determine whether the key, or the
+// deletion endpoint, was not found.
+func (c *Client) deleteNotFoundError(ctx context.Context, fingerprint []byte, err error) error {
+	_, lerr := c.GetKey(ctx, fingerprint)
+	switch {
+	case lerr == nil:
+		return fmt.Errorf("%w: %w", ErrUnsupportedOperation, err)
+	case errors.Is(lerr, ErrKeyNotFound):
+		return fmt.Errorf("%w: %w", ErrKeyNotFound, err)
+	default:
+		return fmt.Errorf("%w", err)
+	}
+}
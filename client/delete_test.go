@@ -0,0 +1,180 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockDeleteKey struct {
+	t          *testing.T
+	code       int
+	wantSearch string
+	keyText    string // Key served in response to lookups, if any.
+}
+
+func (m *MockDeleteKey) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == pathPKSLookup {
+		if got, want := r.URL.Query().Get("search"), m.wantSearch; got != want {
+			m.t.Errorf("got search %v, want %v", got, want)
+		}
+
+		if m.keyText == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, m.keyText)
+		return
+	}
+
+	if got, want := r.Method, http.MethodDelete; got != want {
+		m.t.Errorf("got method %v, want %v", got, want)
+	}
+
+	if got, want := r.URL.Path, pathPKSDelete; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	if got, want := r.URL.Query().Get("search"), m.wantSearch; got != want {
+		m.t.Errorf("got search %v, want %v", got, want)
+	}
+
+	if got, want := r.Header.Get("Authorization"), "BEARER token"; got != want {
+		m.t.Errorf("got authorization %v, want %v", got, want)
+	}
+
+	w.WriteHeader(m.code)
+}
+
+func TestDeleteKey(t *testing.T) {
+	fp := mustDecodeHex(t, aliceFingerprint)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context //nolint:containedctx
+		bearerToken string
+		fingerprint []byte
+		code        int
+		keyText     string
+		wantErr     error
+		wantErrs    []error // Additional errors wrapped by the returned error.
+	}{
+		{
+			name:        "OK",
+			ctx:         context.Background(),
+			bearerToken: "token",
+			fingerprint: fp,
+			code:        http.StatusOK,
+		},
+		{
+			name:        "V6Fingerprint",
+			ctx:         context.Background(),
+			bearerToken: "token",
+			fingerprint: make([]byte, 32),
+			code:        http.StatusNoContent,
+		},
+		{
+			name:        "NotFound",
+			ctx:         context.Background(),
+			bearerToken: "token",
+			fingerprint: fp,
+			code:        http.StatusNotFound,
+			wantErr:     &HTTPError{code: http.StatusNotFound},
+			wantErrs:    []error{ErrKeyNotFound},
+		},
+		{
+			name:        "NotFoundUnsupported",
+			ctx:         context.Background(),
+			bearerToken: "token",
+			fingerprint: fp,
+			keyText:     readTestKey(t, "alice.asc"),
+			code:        http.StatusNotFound,
+			wantErr:     &HTTPError{code: http.StatusNotFound},
+			wantErrs:    []error{ErrUnsupportedOperation},
+		},
+		{
+			name:        "MethodNotAllowed",
+			ctx:         context.Background(),
+			bearerToken: "token",
+			fingerprint: fp,
+			code:        http.StatusMethodNotAllowed,
+			wantErr:     ErrUnsupportedOperation,
+		},
+		{
+			name:        "NotImplemented",
+			ctx:         context.Background(),
+			bearerToken: "token",
+			fingerprint: fp,
+			code:        http.StatusNotImplemented,
+			wantErr:     &HTTPError{code: http.StatusNotImplemented},
+		},
+		{
+			name:        "ContextCanceled",
+			ctx:         cancelled,
+			bearerToken: "token",
+			fingerprint: fp,
+			code:        http.StatusOK,
+			wantErr:     context.Canceled,
+		},
+		{
+			name:        "AuthRequired",
+			ctx:         context.Background(),
+			fingerprint: fp,
+			wantErr:     ErrAuthRequired,
+		},
+		{
+			name:        "InvalidFingerprint",
+			ctx:         context.Background(),
+			bearerToken: "token",
+			fingerprint: fp[12:],
+			wantErr:     ErrInvalidFingerprint,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewTLSServer(&MockDeleteKey{
+				t:          t,
+				code:       tt.code,
+				wantSearch: fmt.Sprintf("%#x", tt.fingerprint),
+				keyText:    tt.keyText,
+			})
+			defer s.Close()
+
+			c, err := NewClient(
+				OptBaseURL(s.URL),
+				OptBearerToken(tt.bearerToken),
+				OptHTTPClient(s.Client()),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			err = c.DeleteKey(tt.ctx, tt.fingerprint)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+			for _, want := range tt.wantErrs {
+				if got := err; !errors.Is(got, want) {
+					t.Errorf("got error %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"regexp"
+	"strings"
+
+	jsonresp "github.com/sylabs/json-resp"
+)
+
+// maxErrorMessageSize is the maximum size of an error message decoded from a plain text or HTML
+// response body.
+const maxErrorMessageSize = 256
+
+// ErrorDecoder extracts a human readable message from the body of an error response.
+type ErrorDecoder interface {
+	// DecodeError returns the message contained in body, which has the specified media type (such
+	// as "text/plain"), or an empty media type if the response did not specify one. If body is not
+	// in a format understood by the decoder, ok is false.
+	DecodeError(mediaType string, body []byte) (message string, ok bool)
+}
+
+// ErrorDecoderFunc is an adapter to allow the use of ordinary functions as an ErrorDecoder.
+type ErrorDecoderFunc func(mediaType string, body []byte) (string, bool)
+
+// DecodeError calls f(mediaType, body).
+func (f ErrorDecoderFunc) DecodeError(mediaType string, body []byte) (string, bool) {
+	return f(mediaType, body)
+}
+
+// OptErrorDecoders adds decoders used to extract messages from error responses, such as those in
+// vendor-specific formats. The decoders are consulted in order, before the built-in decoders, which
+// understand the Sylabs JSON error response, common JSON error shapes, plain text and HTML.
+func OptErrorDecoders(d ...ErrorDecoder) Option {
+	return func(co *clientOptions) error {
+		co.errorDecoders = append(co.errorDecoders, d...)
+		return nil
+	}
+}
+
+// defaultErrorDecoders are the built-in error decoders, in order of precedence. They are consulted
+// after the Sylabs JSON error response envelope.
+var defaultErrorDecoders = []ErrorDecoder{
+	ErrorDecoderFunc(decodeJSONError),
+	ErrorDecoderFunc(decodeHTMLError),
+	ErrorDecoderFunc(decodeTextError),
+}
+
+// errorMediaType returns the media type of contentType in lower case, or an empty string if it is
+// not specified.
+func errorMediaType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return strings.ToLower(mediaType)
+}
+
+// decodeErrorMessage returns the message contained in body, which has the specified media type. The
+// decoders are consulted first, followed by the Sylabs JSON error response envelope, which is
+// recognized regardless of media type, and then the remaining built-in decoders. If the message was
+// taken from the envelope, envelope is true.
+func decodeErrorMessage(decoders []ErrorDecoder, mediaType string, body []byte) (msg string, envelope, ok bool) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return "", false, false
+	}
+
+	for _, d := range decoders {
+		if msg, ok := d.DecodeError(mediaType, body); ok && msg != "" {
+			return msg, false, true
+		}
+	}
+
+	if msg, ok := decodeJSONRespError(body); ok {
+		return msg, true, true
+	}
+
+	for _, d := range defaultErrorDecoders {
+		if msg, ok := d.DecodeError(mediaType, body); ok && msg != "" {
+			return msg, false, true
+		}
+	}
+	return "", false, false
+}
+
+// isJSON returns true if body should be decoded as JSON.
+func isJSON(mediaType string, body []byte) bool {
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return true
+	}
+	b := bytes.TrimSpace(body)
+	return mediaType == "" && len(b) > 0 && b[0] == '{'
+}
+
+// decodeJSONRespError decodes the Sylabs JSON error response envelope.
+func decodeJSONRespError(body []byte) (string, bool) {
+	var jerr *jsonresp.Error
+	if err := jsonresp.ReadError(bytes.NewReader(body)); errors.As(err, &jerr) {
+		return jerr.Message, true
+	}
+	return "", false
+}
+
+// decodeJSONError decodes common JSON error shapes, such as {"error": "..."},
+// {"error": {"message": "..."}}, {"message": "..."}, {"detail": "..."} and
+// {"errors": [{"message": "..."}]}.
+func decodeJSONError(mediaType string, body []byte) (string, bool) {
+	if !isJSON(mediaType, body) {
+		return "", false
+	}
+
+	var v struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Detail  string          `json:"detail"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "", false
+	}
+
+	if len(v.Error) > 0 {
+		var s string
+		if err := json.Unmarshal(v.Error, &s); err == nil && s != "" {
+			return s, true
+		}
+
+		var e struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(v.Error, &e); err == nil && e.Message != "" {
+			return e.Message, true
+		}
+	}
+
+	switch {
+	case v.Message != "":
+		return v.Message, true
+	case v.Detail != "":
+		return v.Detail, true
+	case len(v.Errors) > 0 && v.Errors[0].Message != "":
+		return v.Errors[0].Message, true
+	}
+	return "", false
+}
+
+var (
+	reHTMLTitle   = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	reHTMLHeading = regexp.MustCompile(`(?is)<h1[^>]*>(.*?)</h1>`)
+	reHTMLBody    = regexp.MustCompile(`(?is)<body[^>]*>(.*?)(?:</body>|$)`)
+)
+
+// decodeHTMLError decodes an HTML error page, such as those returned by SKS and Hockeypuck. The
+// message is taken from the first heading (or title), followed by any remaining text of the body.
+func decodeHTMLError(mediaType string, body []byte) (string, bool) {
+	b := bytes.TrimSpace(body)
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" &&
+		(mediaType != "" || len(b) == 0 || b[0] != '<') {
+		return "", false
+	}
+
+	s := string(b)
+
+	var heading string
+	if m := reHTMLHeading.FindStringSubmatch(s); m != nil {
+		heading = htmlText(m[1])
+	} else if m := reHTMLTitle.FindStringSubmatch(s); m != nil {
+		heading = htmlText(m[1])
+	}
+
+	var text string
+	if m := reHTMLBody.FindStringSubmatch(s); m != nil {
+		text = htmlText(reHTMLHeading.ReplaceAllString(m[1], " "))
+	} else {
+		text = htmlText(reHTMLTitle.ReplaceAllString(s, " "))
+	}
+
+	switch {
+	case heading != "" && text != "" && text != heading:
+		return truncateUTF8(heading+": "+text, maxErrorMessageSize), true
+	case heading != "":
+		return truncateUTF8(heading, maxErrorMessageSize), true
+	case text != "":
+		return truncateUTF8(text, maxErrorMessageSize), true
+	}
+	return "", false
+}
+
+// decodeTextError decodes a plain text error body.
+func decodeTextError(mediaType string, body []byte) (string, bool) {
+	if mediaType != "text/plain" && mediaType != "" {
+		return "", false
+	}
+
+	s := strings.Join(strings.Fields(string(body)), " ")
+	return truncateUTF8(s, maxErrorMessageSize), s != ""
+}
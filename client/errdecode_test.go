@@ -0,0 +1,221 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const sksErrorPage = `<html><head><title>Error handling request</title></head>
+<body><h1>Error handling request</h1>Error handling request: No keys found</body></html>`
+
+const hockeypuckErrorPage = `<!DOCTYPE html>
+<html>
+<head><title>Not Found</title><style>body { color: red; }</style></head>
+<body>
+<h2>Not found</h2>
+<p>no keys found for search &quot;alice&quot;</p>
+</body>
+</html>`
+
+func TestDecodeErrorMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		decoders  []ErrorDecoder
+		mediaType string
+		body      string
+		wantOK    bool
+		want      string
+	}{
+		{
+			name:      "Empty",
+			mediaType: "text/plain",
+			body:      " \n",
+		},
+		{
+			name:      "JSONResp",
+			mediaType: "application/json",
+			body:      `{"error":{"code":404,"message":"key not found"}}`,
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name:      "JSONRespAnyMediaType",
+			mediaType: "text/plain",
+			body:      `{"error":{"code":404,"message":"key not found"}}`,
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name:      "JSONErrorString",
+			mediaType: "application/json",
+			body:      `{"error":"key not found"}`,
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name:      "JSONMessage",
+			mediaType: "application/problem+json",
+			body:      `{"message":"key not found"}`,
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name:      "JSONDetail",
+			mediaType: "application/problem+json",
+			body:      `{"title":"Not Found","detail":"key not found"}`,
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name:      "JSONErrors",
+			mediaType: "application/json",
+			body:      `{"errors":[{"message":"key not found"},{"message":"other"}]}`,
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name:      "JSONUnknown",
+			mediaType: "application/json",
+			body:      `{"status":"failed"}`,
+		},
+		{
+			name:      "SniffJSON",
+			mediaType: "",
+			body:      `{"message":"key not found"}`,
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name:      "HTMLSKS",
+			mediaType: "text/html",
+			body:      sksErrorPage,
+			wantOK:    true,
+			want:      "Error handling request: Error handling request: No keys found",
+		},
+		{
+			name:      "HTMLHockeypuck",
+			mediaType: "text/html",
+			body:      hockeypuckErrorPage,
+			wantOK:    true,
+			want:      `Not Found: Not found no keys found for search "alice"`,
+		},
+		{
+			name:      "SniffHTML",
+			mediaType: "",
+			body:      "<html><body>No keys found</body></html>",
+			wantOK:    true,
+			want:      "No keys found",
+		},
+		{
+			name:      "Text",
+			mediaType: "text/plain",
+			body:      "No keys\n  found\n",
+			wantOK:    true,
+			want:      "No keys found",
+		},
+		{
+			name:      "TextTruncated",
+			mediaType: "text/plain",
+			body:      strings.Repeat("x", 2*maxErrorMessageSize),
+			wantOK:    true,
+			want:      strings.Repeat("x", maxErrorMessageSize),
+		},
+		{
+			name:      "UnsupportedMediaType",
+			mediaType: "application/octet-stream",
+			body:      "\x00\x01",
+		},
+		{
+			name: "Custom",
+			decoders: []ErrorDecoder{
+				ErrorDecoderFunc(func(string, []byte) (string, bool) { return "", false }),
+				ErrorDecoderFunc(func(mediaType string, body []byte) (string, bool) {
+					s, ok := strings.CutPrefix(string(body), "ERR ")
+					return s, ok && mediaType == "text/plain"
+				}),
+			},
+			mediaType: "text/plain",
+			body:      "ERR key not found",
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name: "CustomFallback",
+			decoders: []ErrorDecoder{
+				ErrorDecoderFunc(func(string, []byte) (string, bool) { return "", false }),
+			},
+			mediaType: "text/plain",
+			body:      "key not found",
+			wantOK:    true,
+			want:      "key not found",
+		},
+		{
+			name: "CustomBeforeJSONResp",
+			decoders: []ErrorDecoder{
+				ErrorDecoderFunc(func(string, []byte) (string, bool) { return "custom", true }),
+			},
+			mediaType: "application/json",
+			body:      `{"error":{"code":404,"message":"key not found"}}`,
+			wantOK:    true,
+			want:      "custom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, ok := decodeErrorMessage(tt.decoders, tt.mediaType, []byte(tt.body))
+			if ok != tt.wantOK {
+				t.Fatalf("got ok %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("got message %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptErrorDecoders(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.example+xml")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "<error><reason>no such key</reason></error>")
+	}))
+	defer s.Close()
+
+	d := ErrorDecoderFunc(func(mediaType string, body []byte) (string, bool) {
+		if mediaType != "application/vnd.example+xml" {
+			return "", false
+		}
+		_, after, ok := strings.Cut(string(body), "<reason>")
+		reason, _, _ := strings.Cut(after, "</reason>")
+		return reason, ok
+	})
+
+	c, err := NewClient(OptBaseURL(s.URL), OptErrorDecoders(d))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = c.PKSLookup(context.Background(), nil, "search", OperationGet, false, false, nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("got error %v, want HTTPError", err)
+	}
+	if got, want := httpErr.Error(), "404 Not Found: no such key"; got != want {
+		t.Errorf("got message %v, want %v", got, want)
+	}
+	if got, want := httpErr.Body(), "<error><reason>no such key</reason></error>"; got != want {
+		t.Errorf("got body %q, want %q", got, want)
+	}
+}
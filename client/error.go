@@ -1,4 +1,4 @@
-// Copyright (c) 2020, Sylabs Inc. All rights reserved.
+// Copyright (c) 2020-2026, Sylabs Inc. All rights reserved.
 // This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
 // distributed with the sources of this project regarding your rights to use or distribute this
 // software.
@@ -8,20 +8,63 @@ package client
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
-
-	jsonresp "github.com/sylabs/json-resp"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// ErrUnsupportedOperation is returned when the Key Service does not support an operation.
+var ErrUnsupportedOperation = errors.New("operation not supported by key service")
+
 // HTTPError represents an error returned from an HTTP server.
 type HTTPError struct {
-	code int
-	err  error
+	code       int
+	err        error
+	method     string         // Method of the request.
+	url        string         // Redacted URL of the request.
+	header     http.Header    // Response headers.
+	body       string         // Snippet of a response body that could not be decoded.
+	retryAfter *time.Duration // Delay indicated by the "Retry-After" header, if present.
 }
 
 // Code returns the HTTP status code associated with e.
 func (e *HTTPError) Code() int { return e.code }
 
+// Method returns the HTTP method of the request that resulted in e, if known.
+func (e *HTTPError) Method() string { return e.method }
+
+// URL returns the URL of the request that resulted in e, if known. Credentials are redacted.
+func (e *HTTPError) URL() string { return e.url }
+
+// Header returns a copy of the headers of the response that resulted in e.
+func (e *HTTPError) Header() http.Header { return e.header.Clone() }
+
+// RequestID returns the request ID assigned by the server, or an empty string if the response did
+// not include one.
+func (e *HTTPError) RequestID() string {
+	for _, k := range requestIDHeaders {
+		if v := e.header.Get(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// RetryAfter returns the delay indicated by the "Retry-After" header of the response, relative to
+// when the response was received. If the response did not include a valid header, ok is false.
+func (e *HTTPError) RetryAfter() (d time.Duration, ok bool) {
+	if e.retryAfter == nil {
+		return 0, false
+	}
+	return *e.retryAfter, true
+}
+
+// Body returns a snippet of the response body, if it was not a Sylabs JSON error response. The
+// snippet is truncated to at most 1KiB.
+func (e *HTTPError) Body() string { return e.body }
+
 // Unwrap returns the error wrapped by e.
 func (e *HTTPError) Unwrap() error { return e.err }
 
@@ -39,15 +82,105 @@ func (e *HTTPError) Is(target error) bool {
 	return ok && (t.code == e.code)
 }
 
+// requestIDHeaders are the response headers that commonly carry a server request ID.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Request-Id", "X-Amzn-Requestid"}
+
+const (
+	// maxErrorBodySize is the maximum size of an error response body that is read.
+	maxErrorBodySize = 64 << 10
+	// maxErrorSnippetSize is the maximum size of the body snippet retained in an HTTPError.
+	maxErrorSnippetSize = 1 << 10
+)
+
 // errorFromResponse returns an HTTPError containing the status code and detailed error message (if
-// available) from res.
-func errorFromResponse(res *http.Response) error {
-	httpErr := HTTPError{code: res.StatusCode}
+// available) from res, along with details of the request and response. The message is extracted
+// from the body using the configured error decoders.
+func (c *Client) errorFromResponse(res *http.Response) error {
+	httpErr := HTTPError{
+		code:   res.StatusCode,
+		header: res.Header.Clone(),
+	}
+
+	if req := res.Request; req != nil {
+		httpErr.method = req.Method
+		if req.URL != nil {
+			httpErr.url = redactURL(req.URL)
+		}
+	}
+
+	if d, ok := parseRetryAfter(res.Header, time.Now()); ok {
+		httpErr.retryAfter = &d
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+
+	mediaType := errorMediaType(res.Header.Get("Content-Type"))
 
-	var jerr *jsonresp.Error
-	if err := jsonresp.ReadError(res.Body); errors.As(err, &jerr) {
-		httpErr.err = errors.New(jerr.Message)
+	msg, envelope, ok := decodeErrorMessage(c.errorDecoders, mediaType, b)
+	if ok {
+		httpErr.err = errors.New(msg)
+	}
+
+	// The body of a Sylabs JSON error response carries no detail beyond the message.
+	if !envelope {
+		httpErr.body = truncateUTF8(strings.TrimSpace(string(b)), maxErrorSnippetSize)
 	}
 
 	return &httpErr
 }
+
+// truncateUTF8 returns s truncated to at most n bytes, without splitting a UTF-8 sequence.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// httpErrorCode returns the status code of the HTTPError wrapped by err, or zero if there is none.
+func httpErrorCode(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.code
+	}
+	return 0
+}
+
+// IsNotFound returns true if err wraps an HTTPError with status 404 (Not Found).
+func IsNotFound(err error) bool {
+	return httpErrorCode(err) == http.StatusNotFound
+}
+
+// IsRateLimited returns true if err wraps an HTTPError with status 429 (Too Many Requests).
+func IsRateLimited(err error) bool {
+	return httpErrorCode(err) == http.StatusTooManyRequests
+}
+
+// IsUnauthorized returns true if err wraps an HTTPError with status 401 (Unauthorized).
+func IsUnauthorized(err error) bool {
+	return httpErrorCode(err) == http.StatusUnauthorized
+}
+
+// IsTemporary returns true if err wraps an HTTPError with a status that indicates the request may
+// succeed if retried later, such as 429 (Too Many Requests) or 503 (Service Unavailable).
+func IsTemporary(err error) bool {
+	code := httpErrorCode(err)
+	return code == http.StatusRequestTimeout || retryableStatus(code)
+}
+
+// operationErrorFromResponse returns an error describing res, in the same manner as
+// errorFromResponse. If the status code of res indicates the requested operation is not supported
+// by the server, the returned error also wraps ErrUnsupportedOperation.
+func (c *Client) operationErrorFromResponse(res *http.Response) error {
+	err := c.errorFromResponse(res)
+
+	switch res.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return fmt.Errorf("%w: %w", ErrUnsupportedOperation, err)
+	default:
+		return err
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2020, Sylabs Inc. All rights reserved.
+// Copyright (c) 2020-2026, Sylabs Inc. All rights reserved.
 // This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
 // distributed with the sources of this project regarding your rights to use or distribute this
 // software.
@@ -6,9 +6,17 @@
 package client
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	jsonresp "github.com/sylabs/json-resp"
 )
 
 func TestHTTPError(t *testing.T) {
@@ -50,3 +58,167 @@ func TestHTTPError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorFromResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		code           int
+		header         map[string]string
+		body           string
+		jsonMessage    string
+		wantRequestID  string
+		wantRetryAfter time.Duration
+		wantRetryOK    bool
+		wantBody       string
+		wantMessage    string
+	}{
+		{
+			name:          "JSON",
+			code:          http.StatusBadRequest,
+			header:        map[string]string{"X-Request-Id": "abc123"},
+			jsonMessage:   "more good needed",
+			wantRequestID: "abc123",
+			wantMessage:   "400 Bad Request: more good needed",
+		},
+		{
+			name:        "JSONTextPlain",
+			code:        http.StatusBadRequest,
+			header:      map[string]string{"Content-Type": "text/plain"},
+			body:        `{"error":{"code":400,"message":"more good needed"}}`,
+			wantMessage: "400 Bad Request: more good needed",
+		},
+		{
+			name:           "RateLimited",
+			code:           http.StatusTooManyRequests,
+			header:         map[string]string{"Retry-After": "30", "X-Correlation-Id": "def456"},
+			body:           "slow down\n",
+			wantRequestID:  "def456",
+			wantRetryAfter: 30 * time.Second,
+			wantRetryOK:    true,
+			wantBody:       "slow down",
+			wantMessage:    "429 Too Many Requests: slow down",
+		},
+		{
+			name:        "LongBody",
+			code:        http.StatusInternalServerError,
+			body:        strings.Repeat("x", 2*maxErrorSnippetSize),
+			wantBody:    strings.Repeat("x", maxErrorSnippetSize),
+			wantMessage: "500 Internal Server Error: " + strings.Repeat("x", maxErrorMessageSize),
+		},
+		{
+			name:        "HTML",
+			code:        http.StatusNotFound,
+			header:      map[string]string{"Content-Type": "text/html"},
+			body:        "<html><head><title>Error</title></head><body><h1>Not found</h1>No keys found</body></html>",
+			wantBody:    "<html><head><title>Error</title></head><body><h1>Not found</h1>No keys found</body></html>",
+			wantMessage: "404 Not Found: Not found: No keys found",
+		},
+		{
+			name:        "CommonJSON",
+			code:        http.StatusBadRequest,
+			header:      map[string]string{"Content-Type": "application/json"},
+			body:        `{"error":"bad search"}`,
+			wantBody:    `{"error":"bad search"}`,
+			wantMessage: "400 Bad Request: bad search",
+		},
+		{
+			name:        "EmptyBody",
+			code:        http.StatusBadGateway,
+			wantMessage: "502 Bad Gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+
+				if tt.jsonMessage != "" {
+					_ = jsonresp.WriteError(w, tt.jsonMessage, tt.code)
+					return
+				}
+
+				w.WriteHeader(tt.code)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = c.PKSLookup(context.Background(), nil, "search", OperationGet, false, false, nil)
+
+			var httpErr *HTTPError
+			if !errors.As(err, &httpErr) {
+				t.Fatalf("got error %v, want HTTPError", err)
+			}
+
+			if got, want := httpErr.Method(), http.MethodGet; got != want {
+				t.Errorf("got method %v, want %v", got, want)
+			}
+			if got, want := httpErr.URL(), s.URL+pathPKSLookup+"?op=get&search=search"; got != want {
+				t.Errorf("got URL %v, want %v", got, want)
+			}
+			if got, want := httpErr.RequestID(), tt.wantRequestID; got != want {
+				t.Errorf("got request ID %v, want %v", got, want)
+			}
+			d, ok := httpErr.RetryAfter()
+			if d != tt.wantRetryAfter || ok != tt.wantRetryOK {
+				t.Errorf("got retry after %v, %v, want %v, %v", d, ok, tt.wantRetryAfter, tt.wantRetryOK)
+			}
+			if got, want := httpErr.Body(), tt.wantBody; got != want {
+				t.Errorf("got body %q, want %q", got, want)
+			}
+			if got, want := httpErr.Error(), tt.wantMessage; got != want {
+				t.Errorf("got message %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestHTTPErrorHelpers(t *testing.T) {
+	wrap := func(code int) error {
+		return fmt.Errorf("wrapped: %w", &HTTPError{code: code})
+	}
+
+	tests := []struct {
+		name             string
+		err              error
+		wantNotFound     bool
+		wantRateLimited  bool
+		wantUnauthorized bool
+		wantTemporary    bool
+	}{
+		{"NotFound", wrap(http.StatusNotFound), true, false, false, false},
+		{"RateLimited", wrap(http.StatusTooManyRequests), false, true, false, true},
+		{"Unauthorized", wrap(http.StatusUnauthorized), false, false, true, false},
+		{"ServiceUnavailable", wrap(http.StatusServiceUnavailable), false, false, false, true},
+		{"RequestTimeout", wrap(http.StatusRequestTimeout), false, false, false, true},
+		{"Other", errors.New("blah"), false, false, false, false},
+		{"Nil", nil, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := IsNotFound(tt.err), tt.wantNotFound; got != want {
+				t.Errorf("got IsNotFound %v, want %v", got, want)
+			}
+			if got, want := IsRateLimited(tt.err), tt.wantRateLimited; got != want {
+				t.Errorf("got IsRateLimited %v, want %v", got, want)
+			}
+			if got, want := IsUnauthorized(tt.err), tt.wantUnauthorized; got != want {
+				t.Errorf("got IsUnauthorized %v, want %v", got, want)
+			}
+			if got, want := IsTemporary(tt.err), tt.wantTemporary; got != want {
+				t.Errorf("got IsTemporary %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+)
+
+const pathPKSHashQuery = "/pks/hashquery"
+
+// hashSize is the size in bytes of a key hash, as used by SKS-derived key servers for
+// reconciliation.
+const hashSize = 16
+
+// maxHashQueryKeySize is the maximum size in bytes of a single key accepted in a hash query
+// response.
+const maxHashQueryKeySize = 64 << 20
+
+// ErrInvalidHashQuery is returned when a hash query response cannot be parsed.
+var ErrInvalidHashQuery = errors.New("invalid hash query response")
+
+// GetKeyByHash retrieves an ASCII armored keyring from the Key Service using the "hget" operation
+// supported by SKS-derived key servers. The hash is the 128-bit digest used by the key server to
+// identify the key during reconciliation. The context controls the lifetime of the request.
+//
+// If the hash is not 16 bytes long, an error wrapping ErrInvalidSearch is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) GetKeyByHash(ctx context.Context, hash []byte) (keyText string, err error) {
+	if len(hash) != hashSize {
+		return "", fmt.Errorf("%w", ErrInvalidSearch)
+	}
+
+	return c.Lookup(ctx, LookupRequest{
+		Search:    fmt.Sprintf("%X", hash),
+		Operation: OperationHGet,
+	})
+}
+
+// HashQueryStream is a stream of keys returned by HashQuery. It must be closed when no longer
+// required.
+type HashQueryStream struct {
+	rc        io.ReadCloser
+	remaining int
+}
+
+// Len returns the number of keys remaining in the stream.
+func (s *HashQueryStream) Len() int {
+	return s.remaining
+}
+
+// Next returns the next key in the stream as binary OpenPGP packets. When no keys remain, io.EOF is
+// returned. If the response is malformed, an error wrapping ErrInvalidHashQuery is returned.
+func (s *HashQueryStream) Next() ([]byte, error) {
+	if s.remaining == 0 {
+		return nil, io.EOF
+	}
+
+	b, err := readHashQueryItem(s.rc, maxHashQueryKeySize)
+	if err != nil {
+		s.remaining = 0
+		return nil, err
+	}
+	s.remaining--
+
+	return b, nil
+}
+
+// NextArmored returns the next key in the stream in ASCII armored form. When no keys remain, io.EOF
+// is returned. If the response is malformed, an error wrapping ErrInvalidHashQuery is returned.
+func (s *HashQueryStream) NextArmored() (string, error) {
+	b, err := s.Next()
+	if err != nil {
+		return "", err
+	}
+
+	text, err := armor(b, armorTypePublicKey)
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+	return text, nil
+}
+
+// Close closes the stream.
+func (s *HashQueryStream) Close() error {
+	return s.rc.Close()
+}
+
+// HashQuery retrieves the keys identified by hashes from the Key Service, using the bulk
+// "/pks/hashquery" endpoint supported by SKS-derived key servers. Each hash is the 128-bit digest
+// used by the key server to identify a key during reconciliation. The context controls the lifetime
+// of the request, including reading from the returned stream.
+//
+// Key servers silently omit keys they do not hold, so the stream may contain fewer keys than
+// requested. The caller must close the returned stream.
+//
+// If hashes is empty, or any hash is not 16 bytes long, an error wrapping ErrInvalidSearch is
+// returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) HashQuery(ctx context.Context, hashes [][]byte) (hs *HashQueryStream, err error) {
+	ctx, op := c.startOperation(ctx, "HashQuery")
+	defer func() { op.end(err) }()
+
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("%w", ErrInvalidSearch)
+	}
+	for _, h := range hashes {
+		if len(h) != hashSize {
+			return nil, fmt.Errorf("%w", ErrInvalidSearch)
+		}
+	}
+
+	ref := &url.URL{Path: pathPKSHashQuery}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, ref, bytes.NewReader(encodeHashQuery(hashes)))
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	res, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if res.StatusCode/100 != 2 { // non-2xx status code
+		defer res.Body.Close()
+		return nil, fmt.Errorf("%w", c.errorFromResponse(res))
+	}
+
+	n, err := readHashQueryCount(res.Body)
+	if err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+
+	return &HashQueryStream{rc: res.Body, remaining: n}, nil
+}
+
+// encodeHashQuery encodes hashes in the format expected by the "/pks/hashquery" endpoint: a 32-bit
+// big-endian count, followed by each hash prefixed with its 32-bit big-endian length.
+func encodeHashQuery(hashes [][]byte) []byte {
+	var b bytes.Buffer
+
+	_ = binary.Write(&b, binary.BigEndian, uint32(len(hashes))) //nolint:gosec
+	for _, h := range hashes {
+		_ = binary.Write(&b, binary.BigEndian, uint32(len(h))) //nolint:gosec
+		b.Write(h)
+	}
+
+	return b.Bytes()
+}
+
+// readHashQueryCount reads a 32-bit big-endian count from r.
+func readHashQueryCount(r io.Reader) (int, error) {
+	var n uint32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidHashQuery, err)
+	}
+	if n > math.MaxInt32 {
+		return 0, fmt.Errorf("%w: count too large", ErrInvalidHashQuery)
+	}
+	return int(n), nil
+}
+
+// readHashQueryItem reads a length-prefixed item of at most maxSize bytes from r.
+func readHashQueryItem(r io.Reader, maxSize int) ([]byte, error) {
+	n, err := readHashQueryCount(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxSize {
+		return nil, fmt.Errorf("%w: key too large", ErrInvalidHashQuery)
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHashQuery, err)
+	}
+	return b, nil
+}
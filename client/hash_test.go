@@ -0,0 +1,238 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetKeyByHash(t *testing.T) {
+	hash := bytes.Repeat([]byte{0xab}, hashSize)
+
+	tests := []struct {
+		name       string
+		hash       []byte
+		wantSearch string
+		wantErr    error
+	}{
+		{
+			name:       "OK",
+			hash:       hash,
+			wantSearch: "ABABABABABABABABABABABABABABABAB",
+		},
+		{
+			name:    "InvalidHash",
+			hash:    hash[1:],
+			wantErr: ErrInvalidSearch,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := MockPKSLookup{
+				t:        t,
+				response: "Not valid, but it'll do for testing",
+				code:     http.StatusOK,
+				search:   tt.wantSearch,
+				op:       OperationHGet,
+			}
+
+			s := httptest.NewServer(&m)
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			kt, err := c.GetKeyByHash(context.Background(), tt.hash)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if got, want := kt, m.response; got != want {
+					t.Errorf("got key text %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+// MockHashQuery serves keys in response to hash queries.
+type MockHashQuery struct {
+	t        *testing.T
+	code     int
+	keys     map[string][]byte // Binary keys, keyed by hash.
+	response []byte            // If non-nil, returned in place of the keys.
+}
+
+func (m *MockHashQuery) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.URL.Path, pathPKSHashQuery; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+	if got, want := r.Method, http.MethodPost; got != want {
+		m.t.Errorf("got method %v, want %v", got, want)
+	}
+
+	n, err := readHashQueryCount(r.Body)
+	if err != nil {
+		m.t.Fatalf("failed to read count: %v", err)
+	}
+
+	var keys [][]byte
+	for i := 0; i < n; i++ {
+		h, err := readHashQueryItem(r.Body, hashSize)
+		if err != nil {
+			m.t.Fatalf("failed to read hash: %v", err)
+		}
+		if k, ok := m.keys[string(h)]; ok {
+			keys = append(keys, k)
+		}
+	}
+
+	w.WriteHeader(m.code)
+
+	if m.response != nil {
+		_, _ = w.Write(m.response)
+		return
+	}
+
+	_ = binary.Write(w, binary.BigEndian, uint32(len(keys)))
+	for _, k := range keys {
+		_ = binary.Write(w, binary.BigEndian, uint32(len(k)))
+		_, _ = w.Write(k)
+	}
+}
+
+func TestHashQuery(t *testing.T) {
+	alice, err := dearmor(readTestKey(t, "alice.asc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := dearmor(readTestKey(t, "bob.asc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aliceHash := bytes.Repeat([]byte{0x01}, hashSize)
+	bobHash := bytes.Repeat([]byte{0x02}, hashSize)
+	unknownHash := bytes.Repeat([]byte{0x03}, hashSize)
+
+	tests := []struct {
+		name     string
+		code     int
+		hashes   [][]byte
+		response []byte
+		wantErr  error
+		wantKeys [][]byte
+		wantNext error
+	}{
+		{
+			name:     "OK",
+			code:     http.StatusOK,
+			hashes:   [][]byte{aliceHash, unknownHash, bobHash},
+			wantKeys: [][]byte{alice, bob},
+			wantNext: io.EOF,
+		},
+		{
+			name:     "Truncated",
+			code:     http.StatusOK,
+			hashes:   [][]byte{aliceHash},
+			response: []byte{0, 0, 0, 1, 0, 0, 0, 8, 0xc6},
+			wantNext: ErrInvalidHashQuery,
+		},
+		{
+			name:     "MissingCount",
+			code:     http.StatusOK,
+			hashes:   [][]byte{aliceHash},
+			response: []byte{},
+			wantErr:  ErrInvalidHashQuery,
+		},
+		{
+			name:    "HTTPError",
+			code:    http.StatusNotFound,
+			hashes:  [][]byte{aliceHash},
+			wantErr: &HTTPError{code: http.StatusNotFound},
+		},
+		{
+			name:    "NoHashes",
+			wantErr: ErrInvalidSearch,
+		},
+		{
+			name:    "InvalidHash",
+			hashes:  [][]byte{aliceHash[1:]},
+			wantErr: ErrInvalidSearch,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockHashQuery{
+				t:        t,
+				code:     tt.code,
+				keys:     map[string][]byte{string(aliceHash): alice, string(bobHash): bob},
+				response: tt.response,
+			})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			hs, err := c.HashQuery(context.Background(), tt.hashes)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err != nil {
+				return
+			}
+			defer hs.Close()
+
+			for i, want := range tt.wantKeys {
+				if i == 0 {
+					got, err := hs.Next()
+					if err != nil {
+						t.Fatalf("failed to read key: %v", err)
+					}
+					if !bytes.Equal(got, want) {
+						t.Errorf("got unexpected key %v", i)
+					}
+					continue
+				}
+
+				text, err := hs.NextArmored()
+				if err != nil {
+					t.Fatalf("failed to read key: %v", err)
+				}
+				if got, err := dearmor(text); err != nil || !bytes.Equal(got, want) {
+					t.Errorf("got unexpected armored key %v", i)
+				}
+			}
+
+			if _, err := hs.Next(); !errors.Is(err, tt.wantNext) {
+				t.Errorf("got error %v, want %v", err, tt.wantNext)
+			}
+		})
+	}
+}
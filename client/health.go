@@ -0,0 +1,245 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	jsonresp "github.com/sylabs/json-resp"
+)
+
+// ErrHealthMonitorStarted is returned when Run is called on a HealthMonitor more than once.
+var ErrHealthMonitorStarted = errors.New("health monitor already started")
+
+// PingResult describes the result of a successful Ping.
+type PingResult struct {
+	// Time elapsed between sending the request and receiving the response headers.
+	Latency time.Duration
+	// TLS connection details, or nil if the connection does not use TLS.
+	TLS *tls.ConnectionState
+	// Version of the Key Service, or empty if not reported.
+	Version string
+}
+
+// Ping checks that the Key Service is reachable, using the cheapest available endpoint. The
+// version endpoint is used if available. Otherwise, a HEAD request is made to the base URL. The
+// context controls the lifetime of the requests.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) Ping(ctx context.Context) (pr *PingResult, err error) {
+	ctx, op := c.startOperation(ctx, "Ping")
+	defer func() { op.end(err) }()
+
+	pr, err = c.ping(ctx, http.MethodGet, &url.URL{Path: pathVersion})
+	if httpErr := (*HTTPError)(nil); errors.As(err, &httpErr) {
+		switch httpErr.Code() {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return c.ping(ctx, http.MethodHead, &url.URL{})
+		}
+	}
+	return pr, err
+}
+
+// ping makes a request to ref using the specified method, and returns the result.
+func (c *Client) ping(ctx context.Context, method string, ref *url.URL) (*PingResult, error) {
+	req, err := c.NewRequest(ctx, method, ref, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	start := time.Now()
+
+	res, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	defer res.Body.Close()
+
+	pr := PingResult{
+		Latency: time.Since(start),
+		TLS:     res.TLS,
+	}
+
+	if res.StatusCode/100 != 2 { // non-2xx status code
+		return nil, fmt.Errorf("%w", c.errorFromResponse(res))
+	}
+
+	if method == http.MethodGet {
+		// The version is informational, so a malformed response is not considered an error.
+		var sr serverInfoResponse
+		if err := jsonresp.ReadResponse(res.Body, &sr); err == nil {
+			pr.Version = sr.Version
+		}
+	}
+
+	return &pr, nil
+}
+
+// HealthStatus describes the health of the Key Service, as observed by a HealthMonitor.
+type HealthStatus struct {
+	// Whether the Key Service is considered healthy.
+	Healthy bool
+	// Time at which the most recent check completed.
+	Time time.Time
+	// Result of the most recent successful check, if any.
+	Result *PingResult
+	// Error encountered by the most recent check, if it failed.
+	Err error
+	// Number of consecutive failed checks.
+	ConsecutiveFailures int
+}
+
+const (
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 5 * time.Second
+)
+
+// HealthMonitorOptions describes options for a HealthMonitor.
+type HealthMonitorOptions struct {
+	// Interval between checks (defaults to 30 seconds if zero).
+	Interval time.Duration
+	// Timeout for each check (defaults to 5 seconds if zero).
+	Timeout time.Duration
+	// Number of consecutive failed checks before the Key Service is considered unhealthy (defaults
+	// to 1 if zero).
+	FailureThreshold int
+	// Function called when the health of the Key Service changes, if not nil. The function is
+	// called synchronously, so it should not block.
+	OnChange func(HealthStatus)
+}
+
+// HealthMonitor periodically checks the health of the Key Service using Ping.
+type HealthMonitor struct {
+	c       *Client
+	opts    HealthMonitorOptions
+	updates chan HealthStatus
+
+	mu      sync.Mutex
+	status  HealthStatus
+	seen    bool // Whether a check has completed.
+	started bool // Whether Run has been called.
+}
+
+// NewHealthMonitor returns a HealthMonitor that checks the health of the Key Service used by c,
+// according to opts. If opts is nil, default options are used. Checks do not begin until Run is
+// called.
+func (c *Client) NewHealthMonitor(opts *HealthMonitorOptions) *HealthMonitor {
+	var o HealthMonitorOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultHealthInterval
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = defaultHealthTimeout
+	}
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = 1
+	}
+
+	return &HealthMonitor{
+		c:       c,
+		opts:    o,
+		updates: make(chan HealthStatus, 1),
+	}
+}
+
+// Run checks the health of the Key Service immediately, and then at the configured interval,
+// until the context is cancelled, at which point an error wrapping the context error is returned.
+// The channel returned by Updates is closed when Run returns.
+//
+// Run may only be called once. Subsequent calls return an error wrapping ErrHealthMonitorStarted.
+func (m *HealthMonitor) Run(ctx context.Context) error {
+	m.mu.Lock()
+	started := m.started
+	m.started = true
+	m.mu.Unlock()
+
+	if started {
+		return fmt.Errorf("%w", ErrHealthMonitorStarted)
+	}
+
+	defer close(m.updates)
+
+	t := time.NewTicker(m.opts.Interval)
+	defer t.Stop()
+
+	for {
+		m.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w", ctx.Err())
+		case <-t.C:
+		}
+	}
+}
+
+// Status returns the current health status. Before the first check completes, the Key Service is
+// considered unhealthy.
+func (m *HealthMonitor) Status() HealthStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.status
+}
+
+// Updates returns a channel that receives the health status each time the health of the Key
+// Service changes, including the result of the first check. If the receiver falls behind, only
+// the most recent status is retained.
+func (m *HealthMonitor) Updates() <-chan HealthStatus {
+	return m.updates
+}
+
+// check pings the Key Service, and updates the health status.
+func (m *HealthMonitor) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, m.opts.Timeout)
+	defer cancel()
+
+	pr, err := m.c.Ping(ctx)
+
+	m.mu.Lock()
+	prev, seen := m.status, m.seen
+
+	s := HealthStatus{
+		Time:   time.Now(),
+		Result: prev.Result,
+		Err:    err,
+	}
+	if err == nil {
+		s.Healthy = true
+		s.Result = pr
+	} else {
+		s.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+		s.Healthy = prev.Healthy && s.ConsecutiveFailures < m.opts.FailureThreshold
+	}
+
+	m.status, m.seen = s, true
+	m.mu.Unlock()
+
+	if seen && s.Healthy == prev.Healthy {
+		return
+	}
+
+	if m.opts.OnChange != nil {
+		m.opts.OnChange(s)
+	}
+
+	// Replace any status the receiver has not yet consumed.
+	select {
+	case <-m.updates:
+	default:
+	}
+	m.updates <- s
+}
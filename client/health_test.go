@@ -0,0 +1,199 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jsonresp "github.com/sylabs/json-resp"
+)
+
+// MockHealth serves version requests, and optionally HEAD requests to the base URL.
+type MockHealth struct {
+	t         *testing.T
+	noVersion bool        // If true, version requests fail with 404.
+	failing   atomic.Bool // If true, all requests fail with 503.
+}
+
+func (m *MockHealth) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.failing.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/version" && !m.noVersion:
+		vi := struct {
+			Version string `json:"version"`
+		}{Version: "1.2.3"}
+		if err := jsonresp.WriteResponse(w, vi, http.StatusOK); err != nil {
+			m.t.Fatalf("failed to write response: %v", err)
+		}
+
+	case r.Method == http.MethodHead && r.URL.Path == "/":
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name        string
+		tls         bool
+		noVersion   bool
+		failing     bool
+		wantErr     error
+		wantVersion string
+	}{
+		{name: "Version", wantVersion: "1.2.3"},
+		{name: "VersionTLS", tls: true, wantVersion: "1.2.3"},
+		{name: "Head", noVersion: true},
+		{name: "HTTPError", failing: true, wantErr: &HTTPError{code: http.StatusServiceUnavailable}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &MockHealth{t: t, noVersion: tt.noVersion}
+			m.failing.Store(tt.failing)
+
+			var s *httptest.Server
+			if tt.tls {
+				s = httptest.NewTLSServer(m)
+			} else {
+				s = httptest.NewServer(m)
+			}
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL), OptHTTPClient(s.Client()))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			pr, err := c.Ping(context.Background())
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if got, want := pr.Version, tt.wantVersion; got != want {
+					t.Errorf("got version %v, want %v", got, want)
+				}
+				if got, want := pr.TLS != nil, tt.tls; got != want {
+					t.Errorf("got TLS presence %v, want %v", got, want)
+				}
+				if pr.Latency <= 0 {
+					t.Errorf("got non-positive latency %v", pr.Latency)
+				}
+			}
+		})
+	}
+}
+
+// nextStatus returns the next status received from ch.
+func nextStatus(t *testing.T, ch <-chan HealthStatus) HealthStatus {
+	t.Helper()
+
+	select {
+	case s, ok := <-ch:
+		if !ok {
+			t.Fatal("updates channel closed")
+		}
+		return s
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for status")
+	}
+	return HealthStatus{}
+}
+
+func TestHealthMonitor(t *testing.T) {
+	m := &MockHealth{t: t}
+
+	s := httptest.NewServer(m)
+	defer s.Close()
+
+	c, err := NewClient(OptBaseURL(s.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var changes atomic.Int32
+
+	hm := c.NewHealthMonitor(&HealthMonitorOptions{
+		Interval:         time.Millisecond,
+		FailureThreshold: 2,
+		OnChange:         func(HealthStatus) { changes.Add(1) },
+	})
+
+	if hm.Status().Healthy {
+		t.Error("healthy before first check")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- hm.Run(ctx)
+	}()
+
+	st := nextStatus(t, hm.Updates())
+	if !st.Healthy {
+		t.Fatalf("got unhealthy status: %v", st.Err)
+	}
+	if got, want := st.Result.Version, "1.2.3"; got != want {
+		t.Errorf("got version %v, want %v", got, want)
+	}
+
+	m.failing.Store(true)
+
+	st = nextStatus(t, hm.Updates())
+	if st.Healthy {
+		t.Fatal("got healthy status")
+	}
+	if got, want := st.ConsecutiveFailures, 2; got != want {
+		t.Errorf("got %v consecutive failures, want %v", got, want)
+	}
+	if got, want := st.Err, (&HTTPError{code: http.StatusServiceUnavailable}); !errors.Is(got, want) {
+		t.Errorf("got error %v, want %v", got, want)
+	}
+	if st.Result == nil {
+		t.Error("last successful result not retained")
+	}
+
+	m.failing.Store(false)
+
+	if st := nextStatus(t, hm.Updates()); !st.Healthy {
+		t.Fatalf("got unhealthy status: %v", st.Err)
+	}
+
+	cancel()
+	if got, want := <-done, context.Canceled; !errors.Is(got, want) {
+		t.Errorf("got error %v, want %v", got, want)
+	}
+
+	if _, ok := <-hm.Updates(); ok {
+		t.Error("updates channel not closed")
+	}
+
+	// The monitor cannot be run again once stopped.
+	if got, want := hm.Run(context.Background()), ErrHealthMonitorStarted; !errors.Is(got, want) {
+		t.Errorf("got error %v, want %v", got, want)
+	}
+
+	if got, want := changes.Load(), int32(3); got != want {
+		t.Errorf("got %v changes, want %v", got, want)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// reHTMLTag matches an HTML tag.
+	reHTMLTag = regexp.MustCompile(`<[^>]*>`)
+	// reHTMLIgnored matches HTML elements whose content is not visible text.
+	reHTMLIgnored = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(?:script|style)>`)
+)
+
+// htmlText returns the visible text of the HTML fragment s, with whitespace normalized.
+func htmlText(s string) string {
+	s = reHTMLIgnored.ReplaceAllString(s, " ")
+	s = html.UnescapeString(reHTMLTag.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(s), " ")
+}
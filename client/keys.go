@@ -0,0 +1,230 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const defaultGetKeysConcurrency = 4
+
+// GetKeysOptions describes options for GetKeys.
+type GetKeysOptions struct {
+	// Maximum number of concurrent requests (defaults to 4 if zero).
+	Concurrency int
+	// If true, abandon outstanding requests as soon as any key cannot be retrieved.
+	FailFast bool
+	// Maximum number of keys to request with a single HKP request (defaults to 1 if zero). Batched
+	// requests carry a space-separated list of searches, which is only supported by some key
+	// servers. If a batched request fails, or does not return a key, the key is requested
+	// individually.
+	BatchSize int
+}
+
+// KeyResult describes the result of retrieving a single key.
+type KeyResult struct {
+	// ASCII armored keyring (empty if an error was encountered).
+	KeyText string
+	// Error encountered retrieving the key, if any.
+	Err error
+}
+
+// searchKey returns the key used to identify search in the results returned by GetKeys.
+func searchKey(search []byte) string {
+	return fmt.Sprintf("%X", search)
+}
+
+// validSearch returns true if search is a valid key ID or fingerprint.
+func validSearch(search []byte) bool {
+	switch len(search) {
+	case 4, 8, 16, 20:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetKeys retrieves ASCII armored keyrings matching each of searches from the Key Service, in the
+// same manner as GetKey. Keys are retrieved in parallel, according to opts. If opts is nil, default
+// options are used. The context controls the lifetime of the requests.
+//
+// Results are returned in a map keyed by the upper-case hexadecimal encoding of each search. Errors
+// encountered retrieving individual keys are recorded in the corresponding KeyResult.
+//
+// If opts.FailFast is set, outstanding requests are abandoned after the first failure, and an error
+// identifying the failed search and wrapping its error is returned along with the results retrieved
+// up to that point. If the context is cancelled, an error wrapping the context error is returned
+// along with the results retrieved up to that point.
+func (c *Client) GetKeys(ctx context.Context, searches [][]byte, opts *GetKeysOptions) (map[string]KeyResult, error) {
+	var o GetKeysOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultGetKeysConcurrency
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 1
+	}
+
+	results := make(map[string]KeyResult, len(searches))
+
+	// Validate and de-duplicate searches.
+	seen := make(map[string]bool, len(searches))
+	pending := make([][]byte, 0, len(searches))
+	for _, search := range searches {
+		k := searchKey(search)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		if !validSearch(search) {
+			err := fmt.Errorf("%w", ErrInvalidSearch)
+			if o.FailFast {
+				return results, fmt.Errorf("key %v: %w", k, err)
+			}
+			results[k] = KeyResult{Err: err}
+			continue
+		}
+		pending = append(pending, search)
+	}
+
+	// Group searches into batches.
+	var batches [][][]byte
+	for len(pending) > 0 {
+		n := o.BatchSize
+		if n > len(pending) {
+			n = len(pending)
+		}
+		batches = append(batches, pending[:n])
+		pending = pending[n:]
+	}
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+
+	record := func(search []byte, kr KeyResult) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Once failing fast, results from abandoned requests are discarded.
+		if firstErr != nil {
+			return
+		}
+		results[searchKey(search)] = kr
+
+		if kr.Err != nil && o.FailFast {
+			firstErr = fmt.Errorf("key %v: %w", searchKey(search), kr.Err)
+			cancel()
+		}
+	}
+
+	ch := make(chan [][]byte)
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.Concurrency && i < len(batches); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for batch := range ch {
+				c.getKeyBatch(workCtx, batch, record)
+			}
+		}()
+	}
+
+feed:
+	for _, batch := range batches {
+		select {
+		case ch <- batch:
+		case <-workCtx.Done():
+			break feed
+		}
+	}
+	close(ch)
+	wg.Wait()
+
+	if firstErr != nil {
+		return results, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return results, fmt.Errorf("%w", err)
+	}
+	return results, nil
+}
+
+// getKeyBatch retrieves keys matching each search in batch, and calls record with the result for
+// each.
+func (c *Client) getKeyBatch(ctx context.Context, batch [][]byte, record func([]byte, KeyResult)) {
+	remaining := batch
+
+	if len(batch) > 1 {
+		if keyTexts, err := c.lookupBatch(ctx, batch); err == nil {
+			remaining = nil
+
+			for _, search := range batch {
+				if kt, ok := keyTexts[searchKey(search)]; ok {
+					record(search, KeyResult{KeyText: kt})
+				} else {
+					remaining = append(remaining, search)
+				}
+			}
+		}
+	}
+
+	for _, search := range remaining {
+		kt, err := c.GetKey(ctx, search)
+		record(search, KeyResult{KeyText: kt, Err: err})
+	}
+}
+
+// lookupBatch retrieves keys matching the searches in batch with a single request. The returned
+// map contains an ASCII armored keyring for each search that matched one or more of the returned
+// keys, keyed by searchKey.
+func (c *Client) lookupBatch(ctx context.Context, batch [][]byte) (map[string]string, error) {
+	terms := make([]string, 0, len(batch))
+	for _, search := range batch {
+		terms = append(terms, fmt.Sprintf("%#x", search))
+	}
+
+	text, err := c.PKSLookup(ctx, nil, strings.Join(terms, " "), OperationGet, false, true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := parseKeyring([]byte(text))
+	if err != nil {
+		return nil, err
+	}
+
+	keyTexts := make(map[string]string)
+	for _, search := range batch {
+		var matched transferableKey
+		for _, k := range keys {
+			if k.matches(search) {
+				matched = append(matched, k...)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		kt, err := matched.armor()
+		if err != nil {
+			return nil, err
+		}
+		keyTexts[searchKey(search)] = kt
+	}
+
+	return keyTexts, nil
+}
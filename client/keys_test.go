@@ -0,0 +1,243 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// MockKeyServer serves keys in response to "get" lookup requests.
+type MockKeyServer struct {
+	t     *testing.T
+	keys  map[string]string // Armored keys, keyed by upper-case fingerprint.
+	batch bool              // Whether batched searches are supported.
+
+	mu       sync.Mutex
+	searches []string // Searches received.
+}
+
+func (m *MockKeyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.URL.Path, pathPKSLookup; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	search := r.URL.Query().Get("search")
+
+	m.mu.Lock()
+	m.searches = append(m.searches, search)
+	m.mu.Unlock()
+
+	terms := strings.Fields(search)
+	if len(terms) > 1 && !m.batch {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var sb strings.Builder
+	for _, term := range terms {
+		term = strings.ToUpper(strings.TrimPrefix(term, "0x"))
+		for fp, kt := range m.keys {
+			if strings.HasSuffix(fp, term) {
+				sb.WriteString(kt)
+			}
+		}
+	}
+
+	if sb.Len() == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(sb.String()))
+}
+
+func TestGetKeys(t *testing.T) {
+	alice := readTestKey(t, "alice.asc")
+	bob := readTestKey(t, "bob.asc")
+
+	aliceFP := mustDecodeHex(t, aliceFingerprint)
+	bobFP := mustDecodeHex(t, bobFingerprint)
+	unknownFP := make([]byte, 20)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name         string
+		ctx          context.Context //nolint:containedctx
+		batch        bool
+		searches     [][]byte
+		opts         *GetKeysOptions
+		wantErr      error
+		wantErrText  string
+		wantKeys     map[string]string
+		wantErrs     map[string]error
+		wantRequests int
+	}{
+		{
+			name:         "DefaultOptions",
+			ctx:          context.Background(),
+			searches:     [][]byte{aliceFP, bobFP},
+			wantKeys:     map[string]string{aliceFingerprint: alice, bobFingerprint: bob},
+			wantRequests: 2,
+		},
+		{
+			name:         "Duplicates",
+			ctx:          context.Background(),
+			searches:     [][]byte{aliceFP, aliceFP},
+			opts:         &GetKeysOptions{Concurrency: 1},
+			wantKeys:     map[string]string{aliceFingerprint: alice},
+			wantRequests: 1,
+		},
+		{
+			name:     "PartialFailure",
+			ctx:      context.Background(),
+			searches: [][]byte{aliceFP, unknownFP, {0x01}},
+			wantKeys: map[string]string{aliceFingerprint: alice},
+			wantErrs: map[string]error{
+				searchKey(unknownFP): &HTTPError{code: http.StatusNotFound},
+				"01":                 ErrInvalidSearch,
+			},
+			wantRequests: 2,
+		},
+		{
+			name:         "FailFastInvalidSearch",
+			ctx:          context.Background(),
+			searches:     [][]byte{aliceFP, {0x01}},
+			opts:         &GetKeysOptions{FailFast: true},
+			wantErr:      ErrInvalidSearch,
+			wantErrText:  "key 01",
+			wantRequests: 0,
+		},
+		{
+			name:         "FailFast",
+			ctx:          context.Background(),
+			searches:     [][]byte{unknownFP, aliceFP, bobFP},
+			opts:         &GetKeysOptions{Concurrency: 1, FailFast: true},
+			wantErr:      &HTTPError{code: http.StatusNotFound},
+			wantErrText:  "key " + searchKey(unknownFP),
+			wantErrs:     map[string]error{searchKey(unknownFP): &HTTPError{code: http.StatusNotFound}},
+			wantRequests: 1,
+		},
+		{
+			name:         "Batch",
+			ctx:          context.Background(),
+			batch:        true,
+			searches:     [][]byte{aliceFP, bobFP[12:]},
+			opts:         &GetKeysOptions{BatchSize: 2},
+			wantKeys:     map[string]string{aliceFingerprint: alice, searchKey(bobFP[12:]): bob},
+			wantRequests: 1,
+		},
+		{
+			name:     "BatchNotFound",
+			ctx:      context.Background(),
+			batch:    true,
+			searches: [][]byte{aliceFP, unknownFP},
+			opts:     &GetKeysOptions{BatchSize: 2},
+			wantKeys: map[string]string{aliceFingerprint: alice},
+			wantErrs: map[string]error{
+				searchKey(unknownFP): &HTTPError{code: http.StatusNotFound},
+			},
+			wantRequests: 2,
+		},
+		{
+			name:         "BatchUnsupported",
+			ctx:          context.Background(),
+			searches:     [][]byte{aliceFP, bobFP},
+			opts:         &GetKeysOptions{BatchSize: 2},
+			wantKeys:     map[string]string{aliceFingerprint: alice, bobFingerprint: bob},
+			wantRequests: 3,
+		},
+		{
+			name:     "ContextCanceled",
+			ctx:      cancelled,
+			searches: [][]byte{aliceFP},
+			wantErr:  context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := MockKeyServer{
+				t: t,
+				keys: map[string]string{
+					aliceFingerprint: alice,
+					bobFingerprint:   bob,
+				},
+				batch: tt.batch,
+			}
+
+			s := httptest.NewServer(&m)
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			results, err := c.GetKeys(tt.ctx, tt.searches, tt.opts)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+			if err != nil {
+				if got, want := err.Error(), tt.wantErrText; !strings.Contains(got, want) {
+					t.Errorf("got error %q, want it to contain %q", got, want)
+				}
+			}
+
+			if tt.wantRequests != 0 {
+				if got, want := len(m.searches), tt.wantRequests; got != want {
+					t.Errorf("got %v requests, want %v", got, want)
+				}
+			}
+
+			for k, want := range tt.wantKeys {
+				r, ok := results[k]
+				if !ok {
+					t.Errorf("missing result for %v", k)
+					continue
+				}
+				if r.Err != nil {
+					t.Errorf("got error %v for %v", r.Err, k)
+					continue
+				}
+
+				// Keys returned from a batch are re-armored, so compare the decoded contents.
+				got, err := dearmor(r.KeyText)
+				if err != nil {
+					t.Fatalf("failed to dearmor result: %v", err)
+				}
+				if wantData, _ := dearmor(want); string(got) != string(wantData) {
+					t.Errorf("got unexpected key text for %v", k)
+				}
+			}
+
+			for k, want := range tt.wantErrs {
+				r, ok := results[k]
+				if !ok {
+					t.Errorf("missing result for %v", k)
+					continue
+				}
+				if got := r.Err; !errors.Is(got, want) {
+					t.Errorf("got error %v for %v, want %v", got, k, want)
+				}
+			}
+
+			if got, want := len(results), len(tt.wantKeys)+len(tt.wantErrs); got != want {
+				t.Errorf("got %v results, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces sensitive values in log output.
+const redacted = "REDACTED"
+
+// OptLogger sets the logger used by the client. Each HTTP request attempt and retry decision is
+// logged at debug level, and failed operations are logged at warn level.
+//
+// Credentials are never logged: the bearer token and "Authorization" header are omitted, URLs are
+// redacted, and request bodies, including submitted key text, are not logged.
+func OptLogger(l *slog.Logger) Option {
+	return func(co *clientOptions) error {
+		co.logger = l
+		return nil
+	}
+}
+
+// sensitiveQueryParams are query parameters whose values are redacted from logged URLs.
+var sensitiveQueryParams = map[string]bool{
+	"access_token": true,
+	"keysig":       true,
+	"keytext":      true,
+	"token":        true,
+}
+
+// redactURL returns u as a string, with any password and sensitive query parameter values
+// replaced.
+func redactURL(u *url.URL) string {
+	ru := *u
+
+	if q := ru.Query(); len(q) > 0 {
+		changed := false
+		for k, vs := range q {
+			if sensitiveQueryParams[strings.ToLower(k)] {
+				for i := range vs {
+					vs[i] = redacted
+				}
+				changed = true
+			}
+		}
+		if changed {
+			ru.RawQuery = q.Encode()
+		}
+	}
+
+	return ru.Redacted()
+}
+
+// logAttempt logs the outcome of an HTTP request attempt.
+func (c *Client) logAttempt(ctx context.Context, method string, u *url.URL, attempt, code int, d time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", operationFromContext(ctx)),
+		slog.String("method", method),
+		slog.String("url", redactURL(u)),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", d),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	} else {
+		attrs = append(attrs, slog.Int("status", code))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "http request", attrs...)
+}
+
+// logRetry logs a decision to retry an HTTP request after delay d.
+func (c *Client) logRetry(ctx context.Context, method string, u *url.URL, attempt int, d time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "retrying http request",
+		slog.String("operation", operationFromContext(ctx)),
+		slog.String("method", method),
+		slog.String("url", redactURL(u)),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", d),
+	)
+}
+
+// logRetriesExhausted logs a decision not to retry an HTTP request because the maximum number of
+// attempts has been made.
+func (c *Client) logRetriesExhausted(ctx context.Context, method string, u *url.URL, attempts int) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "not retrying http request: attempts exhausted",
+		slog.String("operation", operationFromContext(ctx)),
+		slog.String("method", method),
+		slog.String("url", redactURL(u)),
+		slog.Int("attempts", attempts),
+	)
+}
+
+// logRateLimited logs that the Key Service has asked the client to delay requests by d.
+func (c *Client) logRateLimited(ctx context.Context, method string, u *url.URL, d time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "rate limited by key server",
+		slog.String("operation", operationFromContext(ctx)),
+		slog.String("method", method),
+		slog.String("url", redactURL(u)),
+		slog.Duration("delay", d),
+	)
+}
+
+// logCircuitStateChange logs a change in the state of the circuit breaker.
+func (c *Client) logCircuitStateChange(ctx context.Context, from, to CircuitState) {
+	if c.logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	if to == CircuitOpen {
+		level = slog.LevelWarn
+	}
+
+	c.logger.LogAttrs(ctx, level, "circuit breaker state changed",
+		slog.String("operation", operationFromContext(ctx)),
+		slog.String("from", from.String()),
+		slog.String("to", to.String()),
+	)
+}
+
+// logOperationError logs the failure of the named operation.
+func (c *Client) logOperationError(ctx context.Context, op string, d time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "operation failed",
+		slog.String("operation", op),
+		slog.Duration("duration", d),
+		slog.Any("error", err),
+	)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Operation is a lookup operation, as specified in section 3.1.2 of the OpenPGP HTTP Keyserver
+// Protocol (HKP) specification.
+type Operation string
+
+// The operation constants are untyped so they may continue to be passed to PKSLookup.
+const (
+	// OperationGet is a lookup operation value to perform a "get" operation.
+	OperationGet = "get"
+	// OperationIndex is a lookup operation value to perform a "index" operation.
+	OperationIndex = "index"
+	// OperationVIndex is a lookup operation value to perform a "vindex" operation.
+	OperationVIndex = "vindex"
+	// OperationStats is a lookup operation value to perform a "stats" operation.
+	OperationStats = "stats"
+	// OperationHGet is a lookup operation value to perform a "hget" operation, supported by
+	// SKS-derived key servers.
+	OperationHGet = "hget"
+)
+
+// LookupOption is a lookup option, as specified in section 3.2.1 of the OpenPGP HTTP Keyserver
+// Protocol (HKP) specification.
+type LookupOption string
+
+const (
+	// OptionMachineReadable is a lookup options value to return machine readable output.
+	OptionMachineReadable = "mr"
+	// OptionNoModification is a lookup options value to indicate the keys returned should not be
+	// modified by the Key Service.
+	OptionNoModification = "nm"
+)
+
+// vendorPrefix is the prefix of site-specific operations and options.
+const vendorPrefix = "x-"
+
+// validate returns an error wrapping ErrInvalidOperation if op is not a known operation, or a
+// site-specific extension.
+func (op Operation) validate() error {
+	switch op {
+	case OperationGet, OperationIndex, OperationVIndex, OperationStats, OperationHGet:
+		return nil
+	}
+	if strings.HasPrefix(string(op), vendorPrefix) && len(op) > len(vendorPrefix) {
+		return nil
+	}
+	return fmt.Errorf("%w: unknown operation %q", ErrInvalidOperation, op)
+}
+
+// searchesKeys returns true if op retrieves or lists keys matching a search term.
+func (op Operation) searchesKeys() bool {
+	switch op {
+	case OperationGet, OperationIndex, OperationVIndex, OperationHGet:
+		return true
+	}
+	return false
+}
+
+// validate returns an error wrapping ErrInvalidOperation if o is not a known option, or a
+// site-specific extension.
+func (o LookupOption) validate() error {
+	switch o {
+	case OptionMachineReadable, OptionNoModification:
+		return nil
+	}
+	if strings.HasPrefix(string(o), vendorPrefix) && len(o) > len(vendorPrefix) {
+		return nil
+	}
+	return fmt.Errorf("%w: unknown option %q", ErrInvalidOperation, o)
+}
+
+// LookupRequest describes a lookup request.
+type LookupRequest struct {
+	// Search term. Required for all operations except OperationStats and site-specific extensions.
+	Search string
+	// Operation to perform.
+	Operation Operation
+	// Options modifying the operation.
+	Options []LookupOption
+	// Whether to display fingerprints in index output.
+	Fingerprint bool
+	// Whether the Key Service should perform an exact match on Search.
+	Exact bool
+	// Pagination details (optional). If non-nil, the token is advanced with each request.
+	Page *PageDetails
+}
+
+// validate returns an error if lr is invalid.
+func (lr LookupRequest) validate() error {
+	if err := lr.Operation.validate(); err != nil {
+		return err
+	}
+	for _, o := range lr.Options {
+		if err := o.validate(); err != nil {
+			return err
+		}
+	}
+
+	if lr.Search == "" && lr.Operation.searchesKeys() {
+		return fmt.Errorf("%w", ErrInvalidSearch)
+	}
+	return nil
+}
+
+// values returns the query values corresponding to lr.
+func (lr LookupRequest) values() url.Values {
+	v := url.Values{}
+	if lr.Search != "" {
+		v.Set("search", lr.Search)
+	}
+	v.Set("op", string(lr.Operation))
+	if 0 < len(lr.Options) {
+		options := make([]string, 0, len(lr.Options))
+		for _, o := range lr.Options {
+			options = append(options, string(o))
+		}
+		v.Set("options", strings.Join(options, ","))
+	}
+	if lr.Fingerprint {
+		v.Set("fingerprint", "on")
+	}
+	if lr.Exact {
+		v.Set("exact", "on")
+	}
+	if pd := lr.Page; pd != nil {
+		if pd.Size != 0 {
+			v.Set("x-pagesize", strconv.Itoa(pd.Size))
+		}
+		if pd.Token != "" {
+			v.Set("x-pagetoken", pd.Token)
+		}
+	}
+	return v
+}
+
+// Lookup requests data from the Key Service, as specified in section 3 of the OpenPGP HTTP Keyserver
+// Protocol (HKP) specification. The context controls the lifetime of the request.
+//
+// The request is validated before it is sent. If the operation or an option is not recognised, an
+// error wrapping ErrInvalidOperation is returned. If a search term is required but not supplied, an
+// error wrapping ErrInvalidSearch is returned.
+//
+// Key servers signal that no keys match the search term in different ways: with a 404 (Not Found)
+// status code, an empty response body, or a machine readable index reporting zero keys. For the get,
+// hget, index and vindex operations, all of these result in an error wrapping ErrKeyNotFound. In
+// the case of a 404 status code, the error also wraps the HTTPError.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) Lookup(ctx context.Context, lr LookupRequest) (response string, err error) {
+	ctx, op := c.startOperation(ctx, "PKSLookup",
+		attrSearchType.String(searchType(lr)),
+		attrPageToken.Bool(lr.Page != nil && lr.Page.Token != ""),
+	)
+	defer func() { op.end(err) }()
+
+	if err := lr.validate(); err != nil {
+		return "", err
+	}
+
+	ref := &url.URL{Path: pathPKSLookup, RawQuery: lr.values().Encode()}
+
+	res, err := c.lookup(ctx, ref)
+	if err != nil {
+		if lr.Operation.searchesKeys() && IsNotFound(err) {
+			return "", fmt.Errorf("%w: %w", ErrKeyNotFound, err)
+		}
+		return "", fmt.Errorf("%w", err)
+	}
+
+	if lr.Page != nil {
+		lr.Page.Token = res.nextPageToken
+	}
+
+	if lr.Operation.searchesKeys() && noKeysFound(res.body) {
+		return "", fmt.Errorf("%w", ErrKeyNotFound)
+	}
+
+	return res.body, nil
+}
+
+// noKeysFound returns true if body is a successful lookup response that contains no keys; either an
+// empty body, or a machine readable index reporting zero keys.
+func noKeysFound(body string) bool {
+	line, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	line = strings.TrimSpace(line)
+	return line == "" || line == "info:1:0"
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLookupRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		lr      LookupRequest
+		wantErr error
+	}{
+		{"Get", LookupRequest{Search: "search", Operation: OperationGet}, nil},
+		{"Index", LookupRequest{Search: "search", Operation: OperationIndex}, nil},
+		{"VIndex", LookupRequest{Search: "search", Operation: OperationVIndex}, nil},
+		{"Stats", LookupRequest{Operation: OperationStats}, nil},
+		{"VendorOperation", LookupRequest{Operation: "x-blah"}, nil},
+		{"NoModification", LookupRequest{
+			Search:    "search",
+			Operation: OperationGet,
+			Options:   []LookupOption{OptionNoModification},
+		}, nil},
+		{"VendorOption", LookupRequest{
+			Search:    "search",
+			Operation: OperationIndex,
+			Options:   []LookupOption{OptionMachineReadable, "x-blah"},
+		}, nil},
+		{"MissingOperation", LookupRequest{Search: "search"}, ErrInvalidOperation},
+		{"UnknownOperation", LookupRequest{Search: "search", Operation: "vidnex"}, ErrInvalidOperation},
+		{"EmptyVendorOperation", LookupRequest{Search: "search", Operation: "x-"}, ErrInvalidOperation},
+		{"UnknownOption", LookupRequest{
+			Search:    "search",
+			Operation: OperationGet,
+			Options:   []LookupOption{"blah"},
+		}, ErrInvalidOperation},
+		{"MissingSearch", LookupRequest{Operation: OperationGet}, ErrInvalidSearch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := tt.lr.validate(), tt.wantErr; !errors.Is(got, want) {
+				t.Errorf("got error %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLookupInvalid(t *testing.T) {
+	var requests atomic.Int32
+
+	s := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(OptBaseURL(s.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = c.Lookup(context.Background(), LookupRequest{Search: "search", Operation: "vidnex"})
+	if got, want := err, ErrInvalidOperation; !errors.Is(got, want) {
+		t.Errorf("got error %v, want %v", got, want)
+	}
+
+	if got := requests.Load(); got != 0 {
+		t.Errorf("got %v requests, want 0", got)
+	}
+}
+
+type MockKeyNotFound struct {
+	code     int
+	response string
+}
+
+func (m *MockKeyNotFound) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(m.code)
+	_, _ = io.WriteString(w, m.response)
+}
+
+func TestLookupKeyNotFound(t *testing.T) {
+	getKey := func(c *Client) error {
+		_, err := c.GetKey(context.Background(), []byte{0x01, 0x23, 0x45, 0x67})
+		return err
+	}
+	pksLookup := func(op string) func(c *Client) error {
+		return func(c *Client) error {
+			_, err := c.PKSLookup(context.Background(), nil, "search", op, false, false, []string{OptionMachineReadable})
+			return err
+		}
+	}
+	stats := func(c *Client) error {
+		_, err := c.Lookup(context.Background(), LookupRequest{Operation: OperationStats})
+		return err
+	}
+
+	tests := []struct {
+		name          string
+		code          int
+		response      string
+		fn            func(*Client) error
+		wantNotFound  bool
+		wantHTTPError bool
+	}{
+		{"GetKeyNotFound", http.StatusNotFound, "No keys found", getKey, true, true},
+		{"GetKeyEmpty", http.StatusOK, "", getKey, true, false},
+		{"GetKeyWhitespace", http.StatusOK, " \r\n", getKey, true, false},
+		{"GetKeyIndexHeader", http.StatusOK, "info:1:0\r\n", getKey, true, false},
+		{"GetKeyFound", http.StatusOK, "key", getKey, false, false},
+		{"GetKeyServerError", http.StatusInternalServerError, "", getKey, false, true},
+		{"IndexNotFound", http.StatusNotFound, "", pksLookup(OperationIndex), true, true},
+		{"IndexIndexHeader", http.StatusOK, "info:1:0\n", pksLookup(OperationIndex), true, false},
+		{"IndexFound", http.StatusOK, "info:1:1\npub:0123456789ABCDEF:1:4096:::\n", pksLookup(OperationIndex), false, false},
+		{"HGetEmpty", http.StatusOK, "", pksLookup(OperationHGet), true, false},
+		{"VendorNotFound", http.StatusNotFound, "", pksLookup("x-blah"), false, true},
+		{"VendorEmpty", http.StatusOK, "", pksLookup("x-blah"), false, false},
+		{"StatsEmpty", http.StatusOK, "", stats, false, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockKeyNotFound{code: tt.code, response: tt.response})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			err = tt.fn(c)
+
+			if got, want := errors.Is(err, ErrKeyNotFound), tt.wantNotFound; got != want {
+				t.Errorf("got key not found %v, want %v (error %v)", got, want, err)
+			}
+
+			var httpErr *HTTPError
+			if got, want := errors.As(err, &httpErr), tt.wantHTTPError; got != want {
+				t.Fatalf("got HTTP error %v, want %v (error %v)", got, want, err)
+			}
+			if tt.wantHTTPError {
+				if got, want := httpErr.Code(), tt.code; got != want {
+					t.Errorf("got code %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"strconv"
+	"time"
+)
+
+// Caches reported to Metrics.ObserveCache.
+const (
+	// CacheServerInfo is the server information cache used by GetServerInfo.
+	CacheServerInfo = "server_info"
+	// CacheLookups is the set of in-flight lookups shared between callers when lookup
+	// deduplication is enabled.
+	CacheLookups = "lookups"
+)
+
+// RequestStats describes an HTTP request attempt made to the Key Service.
+type RequestStats struct {
+	// Name of the operation that made the request, such as "PKSLookup".
+	Operation string
+	// HTTP method of the request.
+	Method string
+	// URL path of the request.
+	Path string
+	// Attempt number, starting at one. Attempts greater than one are retries.
+	Attempt int
+	// HTTP status code of the response, or zero if no response was received.
+	StatusCode int
+	// Time taken to receive the response headers.
+	Duration time.Duration
+	// Error encountered sending the request, if any.
+	Err error
+}
+
+// StatusClass returns the class of the status code of the response, such as "2xx", or "error" if
+// no response was received.
+func (rs RequestStats) StatusClass() string {
+	if rs.StatusCode < 100 || rs.StatusCode > 999 {
+		return "error"
+	}
+	return strconv.Itoa(rs.StatusCode/100) + "xx"
+}
+
+// TransferStats describes the size of a response body received from the Key Service.
+type TransferStats struct {
+	// Name of the operation that made the request, such as "PKSLookup".
+	Operation string
+	// HTTP method of the request.
+	Method string
+	// URL path of the request.
+	Path string
+	// Content coding of the response body, or empty if the body was not encoded.
+	Encoding string
+	// Number of bytes read from the network. If the body was decoded transparently by the HTTP
+	// transport, this is -1.
+	WireBytes int64
+	// Number of bytes read after decoding.
+	DecodedBytes int64
+}
+
+// Metrics receives measurements of operations performed by a Client. Implementations must be safe
+// for concurrent use. Embed NopMetrics to implement a subset of the methods.
+type Metrics interface {
+	// ObserveOperation is called when a logical operation, such as "PKSAdd", "PKSLookup", "GetKey"
+	// or "GetVersion", completes. The duration includes retries.
+	ObserveOperation(op string, d time.Duration, err error)
+	// ObserveRequest is called when an HTTP request attempt completes.
+	ObserveRequest(rs RequestStats)
+	// ObserveTransfer is called when a response body is closed.
+	ObserveTransfer(ts TransferStats)
+	// ObserveCache is called when a cache is consulted, indicating whether a result was found.
+	ObserveCache(cache string, hit bool)
+}
+
+// NopMetrics is a Metrics implementation that discards all measurements.
+type NopMetrics struct{}
+
+// ObserveOperation does nothing.
+func (NopMetrics) ObserveOperation(string, time.Duration, error) {}
+
+// ObserveRequest does nothing.
+func (NopMetrics) ObserveRequest(RequestStats) {}
+
+// ObserveTransfer does nothing.
+func (NopMetrics) ObserveTransfer(TransferStats) {}
+
+// ObserveCache does nothing.
+func (NopMetrics) ObserveCache(string, bool) {}
+
+// OptMetrics sets the hooks that receive measurements of operations performed by the client.
+func OptMetrics(m Metrics) Option {
+	return func(co *clientOptions) error {
+		co.metrics = m
+		return nil
+	}
+}
+
+// observeCache reports a cache lookup to the metrics hooks, if configured.
+func (c *Client) observeCache(cache string, hit bool) {
+	if c.metrics != nil {
+		c.metrics.ObserveCache(cache, hit)
+	}
+}
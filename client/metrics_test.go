@@ -0,0 +1,176 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// metricsRecorder records measurements.
+type metricsRecorder struct {
+	mu         sync.Mutex
+	operations []string
+	opErrs     []error
+	requests   []RequestStats
+	transfers  []TransferStats
+	hits       map[string]int
+	misses     map[string]int
+}
+
+func (mr *metricsRecorder) ObserveOperation(op string, _ time.Duration, err error) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	mr.operations = append(mr.operations, op)
+	mr.opErrs = append(mr.opErrs, err)
+}
+
+func (mr *metricsRecorder) ObserveRequest(rs RequestStats) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	mr.requests = append(mr.requests, rs)
+}
+
+func (mr *metricsRecorder) ObserveTransfer(ts TransferStats) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	mr.transfers = append(mr.transfers, ts)
+}
+
+func (mr *metricsRecorder) ObserveCache(cache string, hit bool) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if mr.hits == nil {
+		mr.hits, mr.misses = map[string]int{}, map[string]int{}
+	}
+	if hit {
+		mr.hits[cache]++
+	} else {
+		mr.misses[cache]++
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	var requests int
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		switch r.URL.Path {
+		case "/" + pathVersion:
+			_, _ = io.WriteString(w, `{"data":{"version":"1.0.0"}}`)
+		case pathPKSLookup:
+			// Fail the first lookup, to exercise retries.
+			if requests == 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = io.WriteString(w, "key text")
+		}
+	}))
+	defer s.Close()
+
+	var mr metricsRecorder
+
+	c, err := NewClient(
+		OptBaseURL(s.URL),
+		OptMetrics(&mr),
+		OptRetry(RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetServerInfo(context.Background()); err != nil {
+			t.Fatalf("failed to get server info: %v", err)
+		}
+	}
+
+	if _, err := c.GetKey(context.Background(), mustDecodeHex(t, aliceFingerprint)); err != nil {
+		t.Fatalf("failed to get key: %v", err)
+	}
+
+	if _, err := c.GetKey(context.Background(), []byte{0x01}); !errors.Is(err, ErrInvalidSearch) {
+		t.Fatalf("got error %v, want %v", err, ErrInvalidSearch)
+	}
+
+	if got, want := mr.operations, []string{"GetVersion", "PKSLookup", "GetKey", "GetKey"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got operations %v, want %v", got, want)
+	}
+	if got, want := mr.opErrs[3], ErrInvalidSearch; !errors.Is(got, want) {
+		t.Errorf("got operation error %v, want %v", got, want)
+	}
+
+	wantRequests := []struct {
+		op      string
+		attempt int
+		class   string
+	}{
+		{"GetVersion", 1, "2xx"},
+		{"PKSLookup", 1, "5xx"},
+		{"PKSLookup", 2, "2xx"},
+	}
+	if got, want := len(mr.requests), len(wantRequests); got != want {
+		t.Fatalf("got %v requests, want %v", got, want)
+	}
+	for i, want := range wantRequests {
+		rs := mr.requests[i]
+		if rs.Operation != want.op || rs.Attempt != want.attempt || rs.StatusClass() != want.class {
+			t.Errorf("got request %v/%v/%v, want %v/%v/%v",
+				rs.Operation, rs.Attempt, rs.StatusClass(), want.op, want.attempt, want.class)
+		}
+	}
+
+	if got, want := len(mr.transfers), 2; got != want {
+		t.Fatalf("got %v transfers, want %v", got, want)
+	}
+	if got, want := mr.transfers[1], (TransferStats{
+		Operation:    "PKSLookup",
+		Method:       http.MethodGet,
+		Path:         pathPKSLookup,
+		WireBytes:    int64(len("key text")),
+		DecodedBytes: int64(len("key text")),
+	}); got != want {
+		t.Errorf("got transfer %+v, want %+v", got, want)
+	}
+
+	if got, want := mr.hits[CacheServerInfo], 1; got != want {
+		t.Errorf("got %v server info cache hits, want %v", got, want)
+	}
+	if got, want := mr.misses[CacheServerInfo], 1; got != want {
+		t.Errorf("got %v server info cache misses, want %v", got, want)
+	}
+}
+
+func TestRequestStatsStatusClass(t *testing.T) {
+	tests := []struct {
+		code int
+		want string
+	}{
+		{0, "error"},
+		{http.StatusOK, "2xx"},
+		{http.StatusNotFound, "4xx"},
+		{http.StatusServiceUnavailable, "5xx"},
+	}
+
+	for _, tt := range tests {
+		if got := (RequestStats{StatusCode: tt.code}).StatusClass(); got != tt.want {
+			t.Errorf("got status class %v for %v, want %v", got, tt.code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import "net/http"
+
+// Middleware intercepts HTTP requests made by a Client. A Middleware returns a RoundTripper that
+// typically modifies the request, calls next, and optionally inspects or modifies the response.
+//
+// Middleware is invoked once per attempt, after the "Authorization" and "User-Agent" headers have
+// been set by NewRequest, so it may observe or override them. When OptRetry is used, each retry
+// attempt passes through the middleware chain again. Responses are seen by middleware before
+// transparent decompression (see OptCompression).
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(r).
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// OptMiddleware appends mw to the middleware chain applied to every HTTP request made by the
+// client. Middleware is applied in order, so the first Middleware specified is the outermost, and
+// sees each request first and each response last.
+func OptMiddleware(mw ...Middleware) Option {
+	return func(co *clientOptions) error {
+		co.middleware = append(co.middleware, mw...)
+		return nil
+	}
+}
+
+// chainMiddleware returns a RoundTripper that passes requests through mw in order, before calling
+// next.
+func chainMiddleware(next http.RoundTripper, mw []Middleware) http.RoundTripper {
+	for i := len(mw) - 1; i >= 0; i-- {
+		next = mw[i](next)
+	}
+	return next
+}
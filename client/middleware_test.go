@@ -0,0 +1,100 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recordingMiddleware returns middleware that records name in calls before and after next.
+func recordingMiddleware(name string, mu *sync.Mutex, calls *[]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			*calls = append(*calls, name+":"+r.Header.Get("Authorization"))
+			mu.Unlock()
+
+			r.Header.Add("X-Middleware", name)
+
+			res, err := next.RoundTrip(r)
+
+			mu.Lock()
+			*calls = append(*calls, name+":done")
+			mu.Unlock()
+
+			return res, err
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var headers []string
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Values("X-Middleware")
+
+		switch r.URL.Path {
+		case "/" + pathVersion:
+			_, _ = w.Write([]byte(`{"data":{"version":"1.0.0"}}`))
+		case pathPKSLookup:
+			_, _ = w.Write([]byte("key"))
+		}
+	}))
+	defer s.Close()
+
+	tests := []struct {
+		name string
+		call func(context.Context, *Client) error
+	}{
+		{"PKSAdd", func(ctx context.Context, c *Client) error {
+			return c.PKSAdd(ctx, "key")
+		}},
+		{"PKSLookup", func(ctx context.Context, c *Client) error {
+			_, err := c.PKSLookup(ctx, nil, "search", OperationGet, false, false, nil)
+			return err
+		}},
+		{"GetVersion", func(ctx context.Context, c *Client) error {
+			_, err := c.GetVersion(ctx)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var calls []string
+
+			c, err := NewClient(
+				OptBaseURL(s.URL),
+				OptHTTPClient(s.Client()),
+				OptBearerToken("token"),
+				OptMiddleware(recordingMiddleware("a", &mu, &calls)),
+				OptMiddleware(recordingMiddleware("b", &mu, &calls)),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if err := tt.call(context.Background(), c); err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			want := []string{"a:BEARER token", "b:BEARER token", "b:done", "a:done"}
+			if got := calls; !reflect.DeepEqual(got, want) {
+				t.Errorf("got calls %v, want %v", got, want)
+			}
+
+			if got, want := headers, []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("got headers %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgppacket "github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// errMalformedArmor is returned when ASCII armored data is malformed.
+var errMalformedArmor = errors.New("malformed armor")
+
+// errMalformedPacket is returned when an OpenPGP packet is malformed.
+var errMalformedPacket = errors.New("malformed packet")
+
+// OpenPGP packet tags, as specified in section 5 of RFC 9580.
+const (
+	tagSignature    = 2
+	tagSecretKey    = 5
+	tagPublicKey    = 6
+	tagSecretSubkey = 7
+	tagPublicSubkey = 14
+)
+
+// armorTypePublicKey is the armor header line type for an OpenPGP public key block.
+const armorTypePublicKey = "PGP PUBLIC KEY BLOCK"
+
+// armorWriter encodes data written to it as an ASCII armored block, terminating the footer with a
+// line break so that blocks may be concatenated.
+type armorWriter struct {
+	io.WriteCloser
+	w io.Writer
+}
+
+// Close flushes any buffered data, and writes the checksum and footer.
+func (aw *armorWriter) Close() error {
+	if err := aw.WriteCloser.Close(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(aw.w, "\n")
+	return err
+}
+
+// newArmorWriter returns a writer that encodes data written to it as an ASCII armored block of the
+// specified type to w. The caller must call Close to flush the final line, checksum and footer.
+func newArmorWriter(w io.Writer, blockType string) (io.WriteCloser, error) {
+	enc, err := pgparmor.Encode(w, blockType, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &armorWriter{WriteCloser: enc, w: w}, nil
+}
+
+// armor returns data encoded as an ASCII armored block of the specified type.
+func armor(data []byte, blockType string) (string, error) {
+	var sb strings.Builder
+
+	w, err := newArmorWriter(&sb, blockType)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// dearmor decodes all ASCII armored blocks in text, and returns the concatenation of their
+// contents. Text outside of armored blocks is ignored. If text contains no armored blocks, an
+// error wrapping errMalformedArmor is returned.
+func dearmor(text string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	// The armor decoder re-uses a sufficiently large bufio.Reader, so successive blocks are read
+	// from where the previous block ended.
+	r := bufio.NewReader(strings.NewReader(text))
+
+	blocks := 0
+	for {
+		b, err := pgparmor.Decode(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errMalformedArmor, err)
+		}
+
+		if _, err := io.Copy(&buf, b.Body); err != nil {
+			return nil, fmt.Errorf("%w: %v", errMalformedArmor, err)
+		}
+		blocks++
+	}
+
+	if blocks == 0 {
+		return nil, fmt.Errorf("%w: no armored block found", errMalformedArmor)
+	}
+	return buf.Bytes(), nil
+}
+
+// packet describes an OpenPGP packet.
+type packet struct {
+	tag  uint8  // Packet type ID.
+	raw  []byte // Complete packet, including header.
+	body []byte // Packet body.
+}
+
+// readPackets reads all OpenPGP packets contained in data. The original encoding of each packet
+// is retained, so that keys can be passed on without being re-serialized.
+func readPackets(data []byte) ([]packet, error) {
+	var pkts []packet
+
+	r := bytes.NewReader(data)
+	or := pgppacket.NewOpaqueReader(r)
+
+	for r.Len() > 0 {
+		start := len(data) - r.Len()
+
+		op, err := or.Next()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errMalformedPacket, err)
+		}
+
+		end := len(data) - r.Len()
+		pkts = append(pkts, packet{tag: op.Tag, raw: data[start:end], body: op.Contents})
+	}
+
+	return pkts, nil
+}
+
+// publicKey returns the public key contained within key packet p. If p is not a key packet, or the
+// key version or algorithm is not supported, nil is returned.
+func (p packet) publicKey() *pgppacket.PublicKey {
+	switch p.tag {
+	case tagPublicKey, tagSecretKey, tagPublicSubkey, tagSecretSubkey:
+	default:
+		return nil
+	}
+
+	pp, err := pgppacket.Read(bytes.NewReader(p.raw))
+	if err != nil {
+		return nil
+	}
+
+	switch k := pp.(type) {
+	case *pgppacket.PublicKey:
+		return k
+	case *pgppacket.PrivateKey:
+		return &k.PublicKey
+	}
+	return nil
+}
+
+// fingerprint returns the fingerprint of the key contained within key packet p. If p is not a key
+// packet, or the key version or algorithm is not supported, nil is returned.
+func (p packet) fingerprint() []byte {
+	if pk := p.publicKey(); pk != nil {
+		return pk.Fingerprint
+	}
+	return nil
+}
+
+// transferableKey describes an OpenPGP key and its associated packets, as specified in section
+// 10.1 of RFC 9580.
+type transferableKey []packet
+
+// splitKeys splits pkts into transferable keys. Each key begins with a public or secret key packet.
+// If pkts does not begin with a key packet, an error wrapping errMalformedPacket is returned.
+func splitKeys(pkts []packet) ([]transferableKey, error) {
+	var keys []transferableKey
+
+	for _, p := range pkts {
+		if p.tag == tagPublicKey || p.tag == tagSecretKey {
+			keys = append(keys, transferableKey{p})
+			continue
+		}
+
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("%w: expected key packet, got tag %v", errMalformedPacket, p.tag)
+		}
+		keys[len(keys)-1] = append(keys[len(keys)-1], p)
+	}
+
+	return keys, nil
+}
+
+// parseKeyring decodes text, which may be ASCII armored or binary, and splits it into transferable
+// keys.
+func parseKeyring(text []byte) ([]transferableKey, error) {
+	data := text
+	if len(text) > 0 && text[0]&0x80 == 0 {
+		b, err := dearmor(string(text))
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+
+	pkts, err := readPackets(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitKeys(pkts)
+}
+
+// bytes returns the binary encoding of k.
+func (k transferableKey) bytes() []byte {
+	var b []byte
+	for _, p := range k {
+		b = append(b, p.raw...)
+	}
+	return b
+}
+
+// armor returns k encoded as an ASCII armored public key block.
+func (k transferableKey) armor() (string, error) {
+	return armor(k.bytes(), armorTypePublicKey)
+}
+
+// fingerprint returns the fingerprint of the primary key of k.
+func (k transferableKey) fingerprint() []byte {
+	return k[0].fingerprint()
+}
+
+// fingerprints returns the fingerprints of the primary key and subkeys of k. Keys with an
+// unsupported version or algorithm are omitted.
+func (k transferableKey) fingerprints() [][]byte {
+	var fps [][]byte
+
+	for _, p := range k {
+		if fp := p.fingerprint(); fp != nil {
+			fps = append(fps, fp)
+		}
+	}
+
+	return fps
+}
+
+// matches returns true if search matches the fingerprint or key ID of the primary key or a subkey
+// of k.
+func (k transferableKey) matches(search []byte) bool {
+	for _, fp := range k.fingerprints() {
+		if bytes.Equal(fp, search) || bytes.Equal(keyID(fp, len(search)), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyID returns the n-byte key ID derived from fingerprint fp, as specified in section 5.5.4 of RFC
+// 9580. For version 4 fingerprints the key ID is the low-order bytes of the fingerprint, and for
+// later versions the high-order bytes. If n is larger than 8, nil is returned.
+func keyID(fp []byte, n int) []byte {
+	if n > 8 || n > len(fp) {
+		return nil
+	}
+
+	if len(fp) == v4FingerprintSize {
+		return fp[len(fp)-n:]
+	}
+	return fp[:n]
+}
+
+// v4FingerprintSize is the size of a version 4 key fingerprint.
+const v4FingerprintSize = 20
+
+// sigTypeKeyRevocation is the signature type of a key revocation signature, as specified in section
+// 5.2.1 of RFC 9580.
+const sigTypeKeyRevocation = uint8(pgppacket.SigTypeKeyRevocation)
+
+// signature describes the fields of an OpenPGP signature packet used by this package.
+type signature struct {
+	sigType           uint8  // Signature type ID.
+	issuerKeyID       []byte // Key ID of the issuer, if present.
+	issuerFingerprint []byte // Fingerprint of the issuer, if present.
+}
+
+// issuer returns the fingerprint of the issuer of sig if present, or the key ID otherwise.
+func (sig signature) issuer() []byte {
+	if sig.issuerFingerprint != nil {
+		return sig.issuerFingerprint
+	}
+	return sig.issuerKeyID
+}
+
+// parseSignature parses signature packet p, as specified in section 5.2 of RFC 9580.
+func parseSignature(p packet) (signature, error) {
+	if p.tag != tagSignature {
+		return signature{}, fmt.Errorf("%w: expected signature packet, got tag %v", errMalformedPacket, p.tag)
+	}
+
+	pp, err := pgppacket.Read(bytes.NewReader(p.raw))
+	if err != nil {
+		return signature{}, fmt.Errorf("%w: %v", errMalformedPacket, err)
+	}
+
+	s, ok := pp.(*pgppacket.Signature)
+	if !ok {
+		return signature{}, fmt.Errorf("%w: unexpected signature packet", errMalformedPacket)
+	}
+
+	sig := signature{
+		sigType:           uint8(s.SigType),
+		issuerFingerprint: s.IssuerFingerprint,
+	}
+	if s.IssuerKeyId != nil {
+		sig.issuerKeyID = binary.BigEndian.AppendUint64(nil, *s.IssuerKeyId)
+	}
+	return sig, nil
+}
+
+// verifyKeyRevocation verifies that signature packet sig is a key revocation signature over key
+// packet key, made by key, as specified in section 5.2.4 of RFC 9580.
+func verifyKeyRevocation(key, sig packet) error {
+	pk := key.publicKey()
+	if pk == nil {
+		return fmt.Errorf("%w: unsupported key packet", errMalformedPacket)
+	}
+
+	pp, err := pgppacket.Read(bytes.NewReader(sig.raw))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errMalformedPacket, err)
+	}
+
+	s, ok := pp.(*pgppacket.Signature)
+	if !ok || s.SigType != pgppacket.SigTypeKeyRevocation {
+		return fmt.Errorf("%w: expected key revocation signature", errMalformedPacket)
+	}
+
+	return pk.VerifyRevocationSignature(s)
+}
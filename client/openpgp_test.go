@@ -0,0 +1,300 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgppacket "github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+const (
+	aliceFingerprint = "6927A60652A6966A58BB936819915CCF6F9F5B7A"
+	bobFingerprint   = "116986D84F9738584E2403AD58A2E029D2B4302C"
+)
+
+// readTestKey returns the contents of the named file in the testdata directory.
+func readTestKey(t *testing.T, name string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read test key: %v", err)
+	}
+	return string(b)
+}
+
+// mustDecodeHex returns the bytes represented by the hexadecimal string s.
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("failed to decode hex: %v", err)
+	}
+	return b
+}
+
+func TestArmor(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"Short", []byte("hello")},
+		{"LineLength", bytes.Repeat([]byte{0xaa}, 48)},
+		{"Long", bytes.Repeat([]byte{0x01, 0x02, 0x03}, 1000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := armor(tt.data, armorTypePublicKey)
+			if err != nil {
+				t.Fatalf("failed to armor: %v", err)
+			}
+
+			for _, line := range strings.Split(text, "\n") {
+				if len(line) > 64 {
+					t.Errorf("line too long: %q", line)
+				}
+			}
+
+			b, err := dearmor(text)
+			if err != nil {
+				t.Fatalf("failed to dearmor: %v", err)
+			}
+
+			if got, want := b, tt.data; !bytes.Equal(got, want) {
+				t.Errorf("got data %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestDearmor(t *testing.T) {
+	alice := readTestKey(t, "alice.asc")
+	bob := readTestKey(t, "bob.asc")
+
+	tests := []struct {
+		name     string
+		text     string
+		wantErr  error
+		wantKeys int
+	}{
+		{"NoBlock", "blah", errMalformedArmor, 0},
+		{"NoFooter", strings.Split(alice, "=")[0], errMalformedArmor, 0},
+		{"Single", alice, nil, 1},
+		{"SurroundingText", "prefix\n" + alice + "suffix\n", nil, 1},
+		{"Multiple", alice + bob, nil, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := dearmor(tt.text)
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				pkts, err := readPackets(b)
+				if err != nil {
+					t.Fatalf("failed to read packets: %v", err)
+				}
+
+				keys, err := splitKeys(pkts)
+				if err != nil {
+					t.Fatalf("failed to split keys: %v", err)
+				}
+
+				if got, want := len(keys), tt.wantKeys; got != want {
+					t.Errorf("got %v keys, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadPackets(t *testing.T) {
+	alice, err := dearmor(readTestKey(t, "alice.asc"))
+	if err != nil {
+		t.Fatalf("failed to dearmor: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		wantErr  error
+		wantTags []uint8
+	}{
+		{"Empty", nil, nil, nil},
+		{"NotPacket", []byte{0x00, 0x00}, errMalformedPacket, nil},
+		{"Legacy", []byte{0x98, 0x01, 0xaa, 0x99, 0x00, 0x01, 0xbb}, nil, []uint8{tagPublicKey, tagPublicKey}},
+		{"OpenPGP", []byte{0xc6, 0x01, 0xaa, 0xc2, 0x01, 0xbb}, nil, []uint8{tagPublicKey, tagSignature}},
+		{"Truncated", []byte{0xc6, 0x02, 0xaa}, errMalformedPacket, nil},
+		{"Key", alice, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkts, err := readPackets(tt.data)
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if tt.wantTags != nil {
+					var tags []uint8
+					for _, p := range pkts {
+						tags = append(tags, p.tag)
+					}
+					if got, want := tags, tt.wantTags; !bytes.Equal(got, want) {
+						t.Errorf("got tags %v, want %v", got, want)
+					}
+				}
+
+				// The original encoding of each packet is retained.
+				if got, want := transferableKey(pkts).bytes(), tt.data; !bytes.Equal(got, want) {
+					t.Errorf("got bytes %x, want %x", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPacketFingerprint(t *testing.T) {
+	keys, err := parseKeyring([]byte(readTestKey(t, "alice.asc")))
+	if err != nil {
+		t.Fatalf("failed to parse keyring: %v", err)
+	}
+
+	e, err := openpgp.NewEntity("Test", "", "test@example.com", &pgppacket.Config{Algorithm: pgppacket.PubKeyAlgoEdDSA})
+	if err != nil {
+		t.Fatalf("failed to create entity: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.SerializePrivate(&buf, nil); err != nil {
+		t.Fatalf("failed to serialize entity: %v", err)
+	}
+
+	secret, err := readPackets(buf.Bytes())
+	if err != nil {
+		t.Fatalf("failed to read packets: %v", err)
+	}
+
+	var secretSubkey packet
+	for _, p := range secret {
+		if p.tag == tagSecretSubkey {
+			secretSubkey = p
+		}
+	}
+
+	tests := []struct {
+		name string
+		p    packet
+		want []byte
+	}{
+		{"PublicKey", keys[0][0], mustDecodeHex(t, aliceFingerprint)},
+		{"SecretKey", secret[0], e.PrimaryKey.Fingerprint},
+		{"SecretSubkey", secretSubkey, e.Subkeys[0].PublicKey.Fingerprint},
+		{"Signature", keys[0][len(keys[0])-1], nil},
+		{"UnsupportedVersion", packet{tag: tagPublicKey, raw: []byte{0xc6, 0x01, 0x03}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := tt.p.fingerprint(), tt.want; !bytes.Equal(got, want) {
+				t.Errorf("got fingerprint %X, want %X", got, want)
+			}
+		})
+	}
+}
+
+func TestTransferableKeyMatches(t *testing.T) {
+	keys, err := parseKeyring([]byte(readTestKey(t, "alice.asc")))
+	if err != nil {
+		t.Fatalf("failed to parse keyring: %v", err)
+	}
+	if got, want := len(keys), 1; got != want {
+		t.Fatalf("got %v keys, want %v", got, want)
+	}
+
+	fp := mustDecodeHex(t, aliceFingerprint)
+
+	tests := []struct {
+		name   string
+		search []byte
+		want   bool
+	}{
+		{"Fingerprint", fp, true},
+		{"KeyID", fp[12:], true},
+		{"ShortKeyID", fp[16:], true},
+		{"OtherFingerprint", mustDecodeHex(t, bobFingerprint), false},
+		{"HighOrderBytes", fp[:8], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := keys[0].matches(tt.search), tt.want; got != want {
+				t.Errorf("got match %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	data, err := dearmor(readTestKey(t, "alice.rev"))
+	if err != nil {
+		t.Fatalf("failed to dearmor: %v", err)
+	}
+
+	pkts, err := readPackets(data)
+	if err != nil {
+		t.Fatalf("failed to read packets: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		p               packet
+		wantErr         error
+		wantSigType     uint8
+		wantIssuer      []byte
+		wantIssuerKeyID []byte
+	}{
+		{"NotSignature", packet{tag: tagPublicKey, raw: []byte{0xc6, 0x01, 0x04}}, errMalformedPacket, 0, nil, nil},
+		{"UnsupportedVersion", packet{tag: tagSignature, raw: []byte{0xc2, 0x01, 0x03}}, errMalformedPacket, 0, nil, nil},
+		{"Truncated", packet{tag: tagSignature, raw: append([]byte{0xc2, 0x08}, pkts[0].body[:8]...)}, errMalformedPacket, 0, nil, nil},
+		{"V4", pkts[0], nil, sigTypeKeyRevocation, mustDecodeHex(t, aliceFingerprint), mustDecodeHex(t, aliceFingerprint)[12:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := parseSignature(tt.p)
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if got, want := sig.sigType, tt.wantSigType; got != want {
+					t.Errorf("got signature type %#x, want %#x", got, want)
+				}
+				if got, want := sig.issuer(), tt.wantIssuer; !bytes.Equal(got, want) {
+					t.Errorf("got issuer %x, want %x", got, want)
+				}
+				if got, want := sig.issuerKeyID, tt.wantIssuerKeyID; !bytes.Equal(got, want) {
+					t.Errorf("got issuer key ID %x, want %x", got, want)
+				}
+			}
+		})
+	}
+}
@@ -12,8 +12,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -30,37 +31,94 @@ var ErrInvalidSearch = errors.New("invalid search")
 // ErrInvalidOperation is returned when the operation is invalid.
 var ErrInvalidOperation = errors.New("invalid operation")
 
+// ErrKeyNotFound is returned when the Key Service reports that no keys match a search.
+var ErrKeyNotFound = errors.New("key not found")
+
 // PKSAdd submits an ASCII armored keyring to the Key Service, as specified in section 4 of the
 // OpenPGP HTTP Keyserver Protocol (HKP) specification. The context controls the lifetime of the
 // request.
 //
 // If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
 func (c *Client) PKSAdd(ctx context.Context, keyText string) error {
-	if keyText == "" {
-		return fmt.Errorf("%w", ErrInvalidKeyText)
+	_, err := c.pksAdd(ctx, keyText, nil)
+	return err
+}
+
+// PKSAddWithResult submits an ASCII armored keyring to the Key Service in the same manner as
+// PKSAdd, requesting machine readable output. The response is parsed to determine which keys were
+// inserted, updated or ignored. The context controls the lifetime of the request.
+//
+// Key servers that do not report the keys they processed result in an AddResult without keys.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) PKSAddWithResult(ctx context.Context, keyText string) (*AddResult, error) {
+	body, err := c.pksAdd(ctx, keyText, []string{OptionMachineReadable})
+	if err != nil {
+		return nil, err
 	}
 
-	ref := &url.URL{Path: pathPKSAdd}
+	r, err := parseAddResult(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return r, nil
+}
+
+// pksAdd submits keyText to the Key Service with the supplied options, and returns the response
+// body.
+func (c *Client) pksAdd(ctx context.Context, keyText string, options []string) ([]byte, error) {
+	if keyText == "" {
+		return nil, fmt.Errorf("%w", ErrInvalidKeyText)
+	}
 
 	v := url.Values{}
 	v.Set("keytext", keyText)
+	if 0 < len(options) {
+		v.Set("options", strings.Join(options, ","))
+	}
+
+	return c.postAdd(ctx, contentTypeForm, strings.NewReader(v.Encode()))
+}
 
-	req, err := c.NewRequest(ctx, http.MethodPost, ref, strings.NewReader(v.Encode()))
+// postAdd submits body, a form encoded as described by contentType, to the Key Service, and returns
+// the response body.
+func (c *Client) postAdd(ctx context.Context, contentType string, body io.Reader) (b []byte, err error) {
+	ctx, op := c.startOperation(ctx, "PKSAdd")
+	defer func() { op.end(err) }()
+
+	ref := &url.URL{Path: pathPKSAdd}
+
+	encoding := c.uploadEncoding()
+	if encoding != "" {
+		body = compressBody(body, encoding)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, ref, body)
 	if err != nil {
-		return fmt.Errorf("%w", err)
+		if rc, ok := body.(io.Closer); ok {
+			rc.Close()
+		}
+		return nil, fmt.Errorf("%w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	res, err := c.Do(req)
 	if err != nil {
-		return fmt.Errorf("%w", err)
+		return nil, fmt.Errorf("%w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode/100 != 2 { // non-2xx status code
-		return fmt.Errorf("%w", errorFromResponse(res))
+		return nil, fmt.Errorf("%w", c.errorFromResponse(res))
 	}
-	return nil
+
+	if b, err = io.ReadAll(res.Body); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return b, nil
 }
 
 // PageDetails includes pagination details.
@@ -71,89 +129,97 @@ type PageDetails struct {
 	Token string
 }
 
-const (
-	// OperationGet is a PKSLookup operation value to perform a "get" operation.
-	OperationGet = "get"
-	// OperationIndex is a PKSLookup operation value to perform a "index" operation.
-	OperationIndex = "index"
-	// OperationVIndex is a PKSLookup operation value to perform a "vindex" operation.
-	OperationVIndex = "vindex"
-)
-
-// OptionMachineReadable is a PKSLookup options value to return machine readable output.
-const OptionMachineReadable = "mr"
-
 // PKSLookup requests data from the Key Service, as specified in section 3 of the OpenPGP HTTP
 // Keyserver Protocol (HKP) specification. The context controls the lifetime of the request.
 //
+// PKSLookup is equivalent to calling Lookup with the corresponding LookupRequest.
+//
+// If the Key Service reports that no keys match search, an error wrapping ErrKeyNotFound is
+// returned.
+//
 // If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
 func (c *Client) PKSLookup(ctx context.Context, pd *PageDetails, search, operation string, fingerprint, exact bool, options []string) (response string, err error) {
-	if search == "" {
-		return "", fmt.Errorf("%w", ErrInvalidSearch)
+	lr := LookupRequest{
+		Search:      search,
+		Operation:   Operation(operation),
+		Fingerprint: fingerprint,
+		Exact:       exact,
+		Page:        pd,
 	}
-	if operation == "" {
-		return "", fmt.Errorf("%w", ErrInvalidOperation)
+	for _, o := range options {
+		lr.Options = append(lr.Options, LookupOption(o))
 	}
+	return c.Lookup(ctx, lr)
+}
 
-	v := url.Values{}
-	v.Set("search", search)
-	v.Set("op", operation)
-	if 0 < len(options) {
-		v.Set("options", strings.Join(options, ","))
-	}
-	if fingerprint {
-		v.Set("fingerprint", "on")
-	}
-	if exact {
-		v.Set("exact", "on")
-	}
-	if pd != nil {
-		if pd.Size != 0 {
-			v.Set("x-pagesize", strconv.Itoa(pd.Size))
-		}
-		if pd.Token != "" {
-			v.Set("x-pagetoken", pd.Token)
-		}
+// lookupResult describes the result of a lookup request.
+type lookupResult struct {
+	body          string // Response body.
+	nextPageToken string // Token for next page, if any.
+}
+
+// lookup performs the lookup request identified by ref. If lookup deduplication is enabled,
+// identical in-flight requests are collapsed into a single HTTP request.
+func (c *Client) lookup(ctx context.Context, ref *url.URL) (lookupResult, error) {
+	if !c.dedup {
+		return c.doLookup(ctx, ref)
 	}
 
-	ref := &url.URL{Path: pathPKSLookup, RawQuery: v.Encode()}
+	lr, shared, err := c.lookups.do(ctx, ref.String(), func(ctx context.Context) (lookupResult, error) {
+		return c.doLookup(ctx, ref)
+	})
+	c.observeCache(CacheLookups, shared)
+
+	// The request is recorded in the trace of the caller that started it, so note on the span of
+	// each caller whether it joined another caller's request.
+	trace.SpanFromContext(ctx).SetAttributes(attrLookupShared.Bool(shared))
+	return lr, err
+}
 
+// doLookup sends the lookup request identified by ref, and returns the result.
+func (c *Client) doLookup(ctx context.Context, ref *url.URL) (lookupResult, error) {
 	req, err := c.NewRequest(ctx, http.MethodGet, ref, nil)
 	if err != nil {
-		return "", fmt.Errorf("%w", err)
+		return lookupResult{}, fmt.Errorf("%w", err)
 	}
 
 	res, err := c.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("%w", err)
+		return lookupResult{}, fmt.Errorf("%w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode/100 != 2 { // non-2xx status code
-		return "", fmt.Errorf("%w", errorFromResponse(res))
-	}
-
-	if pd != nil {
-		pd.Token = res.Header.Get("X-HKP-Next-Page-Token")
+		return lookupResult{}, fmt.Errorf("%w", c.errorFromResponse(res))
 	}
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return "", fmt.Errorf("%w", err)
+		return lookupResult{}, fmt.Errorf("%w", err)
 	}
-	return string(body), nil
+
+	return lookupResult{
+		body:          string(body),
+		nextPageToken: res.Header.Get("X-HKP-Next-Page-Token"),
+	}, nil
 }
 
 // GetKey retrieves an ASCII armored keyring matching search from the Key Service. A 32-bit key ID,
 // 64-bit key ID, 128-bit version 3 fingerprint, or 160-bit version 4 fingerprint can be specified
 // in search. The context controls the lifetime of the request.
 //
+// If the Key Service reports that no keys match search, an error wrapping ErrKeyNotFound is
+// returned.
+//
 // If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
 func (c *Client) GetKey(ctx context.Context, search []byte) (keyText string, err error) {
-	switch len(search) {
-	case 4, 8, 16, 20:
-		return c.PKSLookup(ctx, nil, fmt.Sprintf("%#x", search), OperationGet, false, true, nil)
-	default:
+	s := fmt.Sprintf("%#x", search)
+
+	ctx, op := c.startOperation(ctx, "GetKey", attrSearchType.String(searchType(LookupRequest{Search: s})))
+	defer func() { op.end(err) }()
+
+	if !validSearch(search) {
 		return "", fmt.Errorf("%w", ErrInvalidSearch)
 	}
+	return c.PKSLookup(ctx, nil, s, OperationGet, false, true, nil)
 }
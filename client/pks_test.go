@@ -12,6 +12,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
@@ -20,10 +21,12 @@ import (
 )
 
 type MockPKSAdd struct {
-	t       *testing.T
-	code    int
-	message string
-	keyText string
+	t        *testing.T
+	code     int
+	message  string
+	keyText  string
+	options  string
+	response string
 }
 
 func (m *MockPKSAdd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -52,6 +55,13 @@ func (m *MockPKSAdd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if got, want := r.Form.Get("keytext"), m.keyText; got != want {
 		m.t.Errorf("got key text %v, want %v", got, want)
 	}
+	if got, want := r.Form.Get("options"), m.options; got != want {
+		m.t.Errorf("got options %v, want %v", got, want)
+	}
+
+	if _, err := io.Copy(w, strings.NewReader(m.response)); err != nil {
+		m.t.Fatalf("failed to copy: %v", err)
+	}
 }
 
 func TestPKSAdd(t *testing.T) {
@@ -135,6 +145,91 @@ func TestPKSAdd(t *testing.T) {
 	}
 }
 
+func TestPKSAddWithResult(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context //nolint:containedctx
+		keyText  string
+		code     int
+		message  string
+		response string
+		wantErr  error
+		wantKeys []AddedKey
+	}{
+		{
+			name:     "OK",
+			ctx:      context.Background(),
+			keyText:  "key",
+			code:     http.StatusOK,
+			response: `{"inserted":["` + aliceFingerprint + `"],"updated":[],"ignored":[]}`,
+			wantKeys: []AddedKey{{aliceFingerprint, AddStatusInserted}},
+		},
+		{
+			name:    "NoResponse",
+			ctx:     context.Background(),
+			keyText: "key",
+			code:    http.StatusOK,
+		},
+		{
+			name:    "HTTPError",
+			ctx:     context.Background(),
+			keyText: "key",
+			code:    http.StatusBadRequest,
+			wantErr: &HTTPError{code: http.StatusBadRequest},
+		},
+		{
+			name:    "ContextCanceled",
+			ctx:     cancelled,
+			keyText: "key",
+			code:    http.StatusOK,
+			wantErr: context.Canceled,
+		},
+		{
+			name:    "InvalidKeyText",
+			ctx:     context.Background(),
+			keyText: "",
+			wantErr: ErrInvalidKeyText,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockPKSAdd{
+				t:        t,
+				code:     tt.code,
+				message:  tt.message,
+				keyText:  tt.keyText,
+				options:  OptionMachineReadable,
+				response: tt.response,
+			})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			r, err := c.PKSAddWithResult(tt.ctx, tt.keyText)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if got, want := r.Keys, tt.wantKeys; !reflect.DeepEqual(got, want) {
+					t.Errorf("got keys %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
 type MockPKSLookup struct {
 	t             *testing.T
 	code          int
@@ -343,12 +438,12 @@ func TestPKSLookup(t *testing.T) {
 			options: []string{OptionMachineReadable},
 		},
 		{
-			name:    "GetMachineReadableBlah",
+			name:    "GetMachineReadableVendor",
 			ctx:     context.Background(),
 			code:    http.StatusOK,
 			search:  "search",
 			op:      OperationGet,
-			options: []string{OptionMachineReadable, "blah"},
+			options: []string{OptionMachineReadable, "x-blah"},
 		},
 		{
 			name:   "GetExact",
@@ -374,12 +469,12 @@ func TestPKSLookup(t *testing.T) {
 			options: []string{OptionMachineReadable},
 		},
 		{
-			name:    "IndexMachineReadableBlah",
+			name:    "IndexMachineReadableVendor",
 			ctx:     context.Background(),
 			code:    http.StatusOK,
 			search:  "search",
 			op:      OperationIndex,
-			options: []string{OptionMachineReadable, "blah"},
+			options: []string{OptionMachineReadable, "x-blah"},
 		},
 		{
 			name:        "IndexFingerprint",
@@ -413,12 +508,12 @@ func TestPKSLookup(t *testing.T) {
 			options: []string{OptionMachineReadable},
 		},
 		{
-			name:    "VIndexMachineReadableBlah",
+			name:    "VIndexMachineReadableVendor",
 			ctx:     context.Background(),
 			code:    http.StatusOK,
 			search:  "search",
 			op:      OperationVIndex,
-			options: []string{OptionMachineReadable, "blah"},
+			options: []string{OptionMachineReadable, "x-blah"},
 		},
 		{
 			name:        "VIndexFingerprint",
@@ -483,6 +578,15 @@ func TestPKSLookup(t *testing.T) {
 			options: []string{},
 			wantErr: ErrInvalidOperation,
 		},
+		{
+			name:    "InvalidOption",
+			ctx:     context.Background(),
+			code:    http.StatusOK,
+			search:  "search",
+			op:      OperationGet,
+			options: []string{"blah"},
+			wantErr: ErrInvalidOperation,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,265 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrInvalidRateLimit is returned when a rate limit is invalid.
+var ErrInvalidRateLimit = errors.New("invalid rate limit")
+
+// rateLimit describes a token bucket rate limit.
+type rateLimit struct {
+	rps   float64 // Tokens added per second.
+	burst int     // Maximum number of tokens.
+}
+
+// validate returns an error wrapping ErrInvalidRateLimit if rl is invalid.
+func (rl rateLimit) validate() error {
+	if rl.rps <= 0 || math.IsInf(rl.rps, 0) || math.IsNaN(rl.rps) {
+		return fmt.Errorf("%w: rate must be positive", ErrInvalidRateLimit)
+	}
+	if rl.burst < 1 {
+		return fmt.Errorf("%w: burst must be at least one", ErrInvalidRateLimit)
+	}
+	return nil
+}
+
+// OptRateLimit limits the rate of requests sent to the Key Service to rps requests per second, with
+// bursts of up to burst requests. Lookups and uploads have separate budgets, each of which is
+// shared by all operations on the client. By default, both budgets use the specified limit. To
+// limit uploads differently, use OptUploadRateLimit.
+//
+// Each request attempt, including retries, consumes from the budget. Requests that modify the Key
+// Service (such as PKSAdd) consume from the upload budget, and all others from the lookup budget.
+//
+// The limit adapts to the Key Service. When it responds with status 429 and a "Retry-After" header,
+// or reports that the quota is exhausted with "X-RateLimit-Remaining" and "X-RateLimit-Reset"
+// headers, further requests using the same budget are delayed until the indicated time.
+//
+// Waiting for the limiter respects the request context. If the context is cancelled, or its
+// deadline would expire before the request may be sent, an error wrapping the context error is
+// returned without sending the request.
+func OptRateLimit(rps float64, burst int) Option {
+	return func(co *clientOptions) error {
+		rl := rateLimit{rps, burst}
+		if err := rl.validate(); err != nil {
+			return err
+		}
+		co.rateLimit = &rl
+		return nil
+	}
+}
+
+// OptUploadRateLimit limits the rate of upload requests sent to the Key Service to rps requests per
+// second, with bursts of up to burst requests. The upload budget is otherwise managed as described
+// by OptRateLimit, and takes precedence over it.
+func OptUploadRateLimit(rps float64, burst int) Option {
+	return func(co *clientOptions) error {
+		rl := rateLimit{rps, burst}
+		if err := rl.validate(); err != nil {
+			return err
+		}
+		co.uploadRateLimit = &rl
+		return nil
+	}
+}
+
+// tokenBucket is a token bucket rate limiter. Tokens are reserved ahead of time, so the bucket may
+// go into deficit, which is repaid before further requests are permitted.
+type tokenBucket struct {
+	rate  float64 // Tokens added per second.
+	burst float64 // Maximum number of tokens.
+
+	mu          sync.Mutex
+	tokens      float64   // Available tokens, as of last.
+	last        time.Time // Time tokens was last updated.
+	pausedUntil time.Time // Time before which no requests are permitted.
+}
+
+// newTokenBucket returns a full token bucket with the specified limit.
+func newTokenBucket(rl rateLimit) *tokenBucket {
+	return &tokenBucket{
+		rate:   rl.rps,
+		burst:  float64(rl.burst),
+		tokens: float64(rl.burst),
+	}
+}
+
+// advance adds the tokens accumulated up to now. The caller must hold b.mu.
+func (b *tokenBucket) advance(now time.Time) {
+	if b.last.IsZero() {
+		b.last = now
+	}
+	if now.After(b.last) {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// reserve takes a token, and returns the time at which the corresponding request may be sent.
+func (b *tokenBucket) reserve(now time.Time) time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+	b.tokens--
+
+	at := b.last
+	if b.tokens < 0 {
+		at = at.Add(time.Duration(-b.tokens / b.rate * float64(time.Second)))
+	}
+	if at.Before(b.pausedUntil) {
+		at = b.pausedUntil
+	}
+	return at
+}
+
+// cancel returns a token taken by reserve, when the corresponding request will not be sent.
+func (b *tokenBucket) cancel(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+// pause prevents requests being sent before until. Tokens do not accumulate while paused, and at
+// most one token is available when the pause ends.
+func (b *tokenBucket) pause(now, until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+	if until.After(b.last) {
+		b.last = until
+		b.tokens = min(b.tokens, 1)
+	}
+}
+
+// resumeAt returns the time before which no requests are permitted.
+func (b *tokenBucket) resumeAt() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.pausedUntil
+}
+
+// wait blocks until a request may be sent, or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	at := b.reserve(time.Now())
+
+	for {
+		// A pause may have been imposed since the token was reserved.
+		if p := b.resumeAt(); at.Before(p) {
+			at = p
+		}
+
+		d := time.Until(at)
+		if d <= 0 {
+			return nil
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && deadline.Before(at) {
+			b.cancel(time.Now())
+			return fmt.Errorf("rate limit wait of %v would exceed context deadline: %w", d.Round(time.Millisecond), context.DeadlineExceeded)
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			b.cancel(time.Now())
+			return fmt.Errorf("%w", ctx.Err())
+		}
+	}
+}
+
+// isUpload returns true if req modifies the Key Service, and so consumes from the upload budget.
+func isUpload(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return false
+	}
+	return req.URL.Path != pathPKSHashQuery
+}
+
+// rateLimiter returns the token bucket used to limit req, or nil if it is not limited.
+func (c *Client) rateLimiter(req *http.Request) *tokenBucket {
+	if isUpload(req) {
+		return c.uploadLimiter
+	}
+	return c.lookupLimiter
+}
+
+// waitRateLimit blocks until req may be sent according to the rate limit, or its context is done.
+func (c *Client) waitRateLimit(req *http.Request) error {
+	if b := c.rateLimiter(req); b != nil {
+		return b.wait(req.Context())
+	}
+	return nil
+}
+
+// adaptRateLimit pauses the rate limiter used for req if res indicates the Key Service is limiting
+// requests.
+func (c *Client) adaptRateLimit(req *http.Request, res *http.Response) {
+	b := c.rateLimiter(req)
+	if b == nil || res == nil {
+		return
+	}
+
+	now := time.Now()
+	if until, ok := rateLimitedUntil(res, now); ok {
+		c.logRateLimited(req.Context(), req.Method, req.URL, until.Sub(now))
+		b.pause(now, until)
+	}
+}
+
+// rateLimitedUntil returns the time until which the Key Service has indicated in res that requests
+// will be rejected, relative to now.
+func rateLimitedUntil(res *http.Response, now time.Time) (time.Time, bool) {
+	if res.StatusCode == http.StatusTooManyRequests {
+		if d, ok := parseRetryAfter(res.Header, now); ok {
+			return now.Add(d), true
+		}
+	}
+
+	if res.Header.Get("X-RateLimit-Remaining") != "0" {
+		return time.Time{}, false
+	}
+	return parseRateLimitReset(res.Header, now)
+}
+
+// resetEpochThreshold is the value above which an "X-RateLimit-Reset" header is interpreted as a
+// Unix time, rather than a number of seconds.
+const resetEpochThreshold = 1e9
+
+// parseRateLimitReset returns the time indicated by the "X-RateLimit-Reset" header in h, which may
+// be expressed as a number of seconds relative to now, or as a Unix time.
+func parseRateLimitReset(h http.Header, now time.Time) (time.Time, bool) {
+	s, err := strconv.ParseFloat(h.Get("X-RateLimit-Reset"), 64)
+	if err != nil || s < 0 || math.IsInf(s, 0) {
+		return time.Time{}, false
+	}
+
+	if s >= resetEpochThreshold {
+		sec, frac := math.Modf(s)
+		return time.Unix(int64(sec), int64(frac*1e9)), true
+	}
+	return now.Add(time.Duration(s * float64(time.Second))), true
+}
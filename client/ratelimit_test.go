@@ -0,0 +1,318 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	t0 := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	ms := func(n int) time.Time { return t0.Add(time.Duration(n) * time.Millisecond) }
+
+	b := newTokenBucket(rateLimit{rps: 2, burst: 2})
+
+	// Burst is available immediately, after which tokens are reserved at the configured rate.
+	if got, want := b.reserve(t0), t0; !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := b.reserve(t0), t0; !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := b.reserve(t0), ms(500); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// A cancelled reservation is returned to the bucket.
+	b.cancel(t0)
+	if got, want := b.reserve(ms(250)), ms(500); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Tokens do not accumulate while paused.
+	b.pause(ms(250), ms(3000))
+	if got, want := b.resumeAt(), ms(3000); !got.Equal(want) {
+		t.Errorf("got resume at %v, want %v", got, want)
+	}
+	if got, want := b.reserve(ms(1000)), ms(3750); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// An earlier pause does not shorten the current one.
+	b.pause(ms(1000), ms(2000))
+	if got, want := b.resumeAt(), ms(3000); !got.Equal(want) {
+		t.Errorf("got resume at %v, want %v", got, want)
+	}
+
+	// Tokens accumulate up to the burst size.
+	if got, want := b.reserve(ms(100000)), ms(100000); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := b.reserve(ms(100000)), ms(100000); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := b.reserve(ms(100000)), ms(100500); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRateLimitedUntil(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		code   int
+		header map[string]string
+		wantOK bool
+		want   time.Time
+	}{
+		{
+			name: "OK",
+			code: http.StatusOK,
+		},
+		{
+			name:   "RetryAfter",
+			code:   http.StatusTooManyRequests,
+			header: map[string]string{"Retry-After": "30"},
+			wantOK: true,
+			want:   now.Add(30 * time.Second),
+		},
+		{
+			name:   "RetryAfterDate",
+			code:   http.StatusTooManyRequests,
+			header: map[string]string{"Retry-After": "Sun, 18 Oct 2026 12:01:00 GMT"},
+			wantOK: true,
+			want:   now.Add(time.Minute),
+		},
+		{
+			name:   "RetryAfterIgnored",
+			code:   http.StatusServiceUnavailable,
+			header: map[string]string{"Retry-After": "30"},
+		},
+		{
+			name:   "TooManyRequests",
+			code:   http.StatusTooManyRequests,
+			header: map[string]string{},
+		},
+		{
+			name:   "ResetSeconds",
+			code:   http.StatusOK,
+			header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1.5"},
+			wantOK: true,
+			want:   now.Add(1500 * time.Millisecond),
+		},
+		{
+			name:   "ResetUnix",
+			code:   http.StatusTooManyRequests,
+			header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1792324800"},
+			wantOK: true,
+			want:   time.Unix(1792324800, 0),
+		},
+		{
+			name:   "Remaining",
+			code:   http.StatusOK,
+			header: map[string]string{"X-RateLimit-Remaining": "10", "X-RateLimit-Reset": "60"},
+		},
+		{
+			name:   "ResetInvalid",
+			code:   http.StatusOK,
+			header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "soon"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{StatusCode: tt.code, Header: http.Header{}}
+			for k, v := range tt.header {
+				res.Header.Set(k, v)
+			}
+
+			got, ok := rateLimitedUntil(res, now)
+			if ok != tt.wantOK {
+				t.Fatalf("got ok %v, want %v", ok, tt.wantOK)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type MockRateLimit struct {
+	code     int
+	header   map[string]string
+	requests atomic.Int32
+}
+
+func (m *MockRateLimit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.requests.Add(1)
+
+	if r.URL.Path == pathPKSLookup {
+		for k, v := range m.header {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(m.code)
+	}
+	_, _ = w.Write([]byte("key"))
+}
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		code         int
+		header       map[string]string
+		wantFirstErr error
+		wantErr      error
+		wantDelay    time.Duration
+		wantRequests int32
+	}{
+		{
+			name:         "Wait",
+			opts:         []Option{OptRateLimit(10, 1)},
+			code:         http.StatusOK,
+			wantDelay:    80 * time.Millisecond,
+			wantRequests: 3,
+		},
+		{
+			name:         "Exhausted",
+			opts:         []Option{OptRateLimit(0.1, 1)},
+			code:         http.StatusOK,
+			wantErr:      context.DeadlineExceeded,
+			wantRequests: 2,
+		},
+		{
+			name:         "RetryAfter",
+			opts:         []Option{OptRateLimit(100, 10)},
+			code:         http.StatusTooManyRequests,
+			header:       map[string]string{"Retry-After": "60"},
+			wantFirstErr: &HTTPError{code: http.StatusTooManyRequests},
+			wantErr:      context.DeadlineExceeded,
+			wantRequests: 2,
+		},
+		{
+			name:         "RateLimitHeaders",
+			opts:         []Option{OptRateLimit(100, 10)},
+			code:         http.StatusOK,
+			header:       map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "60"},
+			wantErr:      context.DeadlineExceeded,
+			wantRequests: 2,
+		},
+		{
+			name:         "Unlimited",
+			code:         http.StatusOK,
+			header:       map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "60"},
+			wantRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &MockRateLimit{code: tt.code, header: tt.header}
+			s := httptest.NewServer(m)
+			defer s.Close()
+
+			c, err := NewClient(append([]Option{OptBaseURL(s.URL)}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			lookup := func() error {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+
+				_, err := c.PKSLookup(ctx, nil, "search", OperationGet, false, false, nil)
+				return err
+			}
+
+			// The first lookup consumes the budget, or causes the limiter to adapt.
+			if got, want := lookup(), tt.wantFirstErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			// Uploads have a separate budget.
+			if err := c.PKSAdd(context.Background(), "key"); err != nil {
+				t.Fatalf("failed to add key: %v", err)
+			}
+
+			start := time.Now()
+			if got, want := lookup(), tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+			if got, want := time.Since(start), tt.wantDelay; got < want {
+				t.Errorf("got delay %v, want at least %v", got, want)
+			}
+
+			if got, want := m.requests.Load(), tt.wantRequests; got != want {
+				t.Errorf("got %v requests, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRateLimitContextCanceled(t *testing.T) {
+	m := &MockRateLimit{code: http.StatusOK}
+	s := httptest.NewServer(m)
+	defer s.Close()
+
+	c, err := NewClient(OptBaseURL(s.URL), OptRateLimit(100, 10), OptUploadRateLimit(0.1, 1))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if err := c.PKSAdd(context.Background(), "key"); err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if got, want := c.PKSAdd(ctx, "key"), context.Canceled; !errors.Is(got, want) {
+		t.Errorf("got error %v, want %v", got, want)
+	}
+
+	// Lookups are not limited by the upload budget.
+	if _, err := c.PKSLookup(context.Background(), nil, "search", OperationGet, false, false, nil); err != nil {
+		t.Errorf("failed to lookup key: %v", err)
+	}
+
+	if got, want := m.requests.Load(), int32(2); got != want {
+		t.Errorf("got %v requests, want %v", got, want)
+	}
+}
+
+func TestOptRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     Option
+		wantErr error
+	}{
+		{"OK", OptRateLimit(1, 1), nil},
+		{"ZeroRate", OptRateLimit(0, 1), ErrInvalidRateLimit},
+		{"NegativeRate", OptRateLimit(-1, 1), ErrInvalidRateLimit},
+		{"ZeroBurst", OptRateLimit(1, 0), ErrInvalidRateLimit},
+		{"UploadOK", OptUploadRateLimit(1, 1), nil},
+		{"UploadZeroBurst", OptUploadRateLimit(1, 0), ErrInvalidRateLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(tt.opt)
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Errorf("got error %v, want %v", got, want)
+			}
+		})
+	}
+}
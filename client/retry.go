@@ -0,0 +1,238 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	defaultRetryMinBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy describes how failed requests are retried.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first. Values less than two disable retries.
+	MaxAttempts int
+	// Delay before the first retry (defaults to 100ms if zero). The delay doubles with each
+	// subsequent retry.
+	MinBackoff time.Duration
+	// Maximum delay between attempts (defaults to 5s if zero). A "Retry-After" header supplied by
+	// the Key Service is honoured up to this limit.
+	MaxBackoff time.Duration
+}
+
+// OptRetry sets the policy used to retry failed requests.
+//
+// Requests are retried when a network error occurs, or the Key Service responds with status 429,
+// 502, 503 or 504. Requests with a body are only retried if the body can be replayed, which is the
+// case for all requests made by the client except streamed uploads. Uploads compressed as a result
+// of OptCompressUploads are streamed, so they are never retried.
+func OptRetry(p RetryPolicy) Option {
+	return func(co *clientOptions) error {
+		if p.MinBackoff <= 0 {
+			p.MinBackoff = defaultRetryMinBackoff
+		}
+		if p.MaxBackoff <= 0 {
+			p.MaxBackoff = defaultRetryMaxBackoff
+		}
+		co.retry = p
+		return nil
+	}
+}
+
+// retryableStatus returns true if a response with the specified status code may be retried.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryable returns true if req may be retried following the supplied response and error.
+func retryable(req *http.Request, res *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		if req.Context().Err() != nil || errors.Is(err, context.Canceled) {
+			return false
+		}
+
+		// Attempts that exceed a connect, TLS handshake or first byte timeout may be retried.
+		var te *TimeoutError
+		if errors.As(err, &te) {
+			return te.Phase != TimeoutTotal
+		}
+		return !errors.Is(err, context.DeadlineExceeded)
+	}
+	return retryableStatus(res.StatusCode)
+}
+
+// parseRetryAfter returns the delay indicated by the "Retry-After" header in h, which may be
+// expressed in seconds or as an HTTP date relative to now.
+func parseRetryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// backoff returns the delay before the specified retry attempt (starting at one), taking into
+// account the response to the previous attempt, if any.
+func (p RetryPolicy) backoff(retry int, res *http.Response) time.Duration {
+	if res != nil {
+		if d, ok := parseRetryAfter(res.Header, time.Now()); ok {
+			return min(d, p.MaxBackoff)
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, p.MaxBackoff)
+
+	// Apply jitter to spread out retries from concurrent clients.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1)) //nolint:gosec
+}
+
+// doRetry sends req using the middleware chain, retrying according to the retry policy.
+func (c *Client) doRetry(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("%w", err)
+			}
+			req.Body = body
+		}
+
+		done, err := c.circuitAllow(req)
+		if err != nil {
+			closeRequestBody(req)
+			return nil, err
+		}
+
+		if err := c.waitRateLimit(req); err != nil {
+			done(circuitIgnored)
+			closeRequestBody(req)
+			return nil, err
+		}
+
+		res, err := c.roundTripAttempt(req, attempt)
+		done(circuitOutcomeOf(req, res, err))
+		c.adaptRateLimit(req, res)
+
+		if !retryable(req, res, err) {
+			return res, err
+		}
+		if attempt >= c.retry.MaxAttempts {
+			if c.retry.MaxAttempts > 1 {
+				c.logRetriesExhausted(req.Context(), req.Method, req.URL, attempt)
+			}
+			return res, err
+		}
+
+		d := c.retry.backoff(attempt, res)
+		c.logRetry(req.Context(), req.Method, req.URL, attempt, d)
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+			res.Body.Close()
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// closeRequestBody closes the body of req, if any, when it will not be sent.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// roundTripAttempt sends req using the middleware chain, recording the attempt in a child span of
+// the current operation.
+func (c *Client) roundTripAttempt(req *http.Request, attempt int) (*http.Response, error) {
+	ctx, span := c.startAttempt(req.Context(), req.Method, req.URL.Path, attempt, propagation.HeaderCarrier(req.Header))
+	defer span.End()
+
+	start := time.Now()
+
+	ctx, finish := c.withPhaseTimeouts(ctx)
+	res, err := finish(c.transport.RoundTrip(req.WithContext(ctx)))
+
+	code := 0
+	if res != nil {
+		code = res.StatusCode
+	}
+	c.logAttempt(ctx, req.Method, req.URL, attempt, code, time.Since(start), err)
+
+	if m := c.metrics; m != nil {
+		rs := RequestStats{
+			Operation:  operationFromContext(ctx),
+			Method:     req.Method,
+			Path:       req.URL.Path,
+			Attempt:    attempt,
+			StatusCode: code,
+			Duration:   time.Since(start),
+			Err:        err,
+		}
+		m.ObserveRequest(rs)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attrStatusCode.Int(res.StatusCode))
+	if res.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+	return res, nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// MockFlaky fails the first failures requests with code, then succeeds.
+type MockFlaky struct {
+	t          *testing.T
+	failures   int32
+	code       int
+	retryAfter string
+
+	requests atomic.Int32
+}
+
+func (m *MockFlaky) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := m.requests.Add(1)
+
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			m.t.Fatalf("failed to parse form: %v", err)
+		}
+		if got, want := r.Form.Get("keytext"), "key"; got != want {
+			m.t.Errorf("got key text %q, want %q", got, want)
+		}
+	}
+
+	if n <= m.failures {
+		if m.retryAfter != "" {
+			w.Header().Set("Retry-After", m.retryAfter)
+		}
+		w.WriteHeader(m.code)
+		return
+	}
+	_, _ = io.WriteString(w, "key")
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       *RetryPolicy
+		failures     int32
+		code         int
+		retryAfter   string
+		streamed     bool
+		wantErr      error
+		wantRequests int32
+	}{
+		{
+			name:         "Disabled",
+			failures:     1,
+			code:         http.StatusServiceUnavailable,
+			wantErr:      &HTTPError{code: http.StatusServiceUnavailable},
+			wantRequests: 1,
+		},
+		{
+			name:         "Recovered",
+			policy:       &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+			failures:     2,
+			code:         http.StatusServiceUnavailable,
+			wantRequests: 3,
+		},
+		{
+			name:         "Exhausted",
+			policy:       &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond},
+			failures:     2,
+			code:         http.StatusTooManyRequests,
+			wantErr:      &HTTPError{code: http.StatusTooManyRequests},
+			wantRequests: 2,
+		},
+		{
+			name:         "NotRetryable",
+			policy:       &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+			failures:     1,
+			code:         http.StatusBadRequest,
+			wantErr:      &HTTPError{code: http.StatusBadRequest},
+			wantRequests: 1,
+		},
+		{
+			name:         "RetryAfter",
+			policy:       &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Hour, MaxBackoff: time.Hour},
+			failures:     1,
+			code:         http.StatusTooManyRequests,
+			retryAfter:   "0",
+			wantRequests: 2,
+		},
+		{
+			name:         "StreamedUpload",
+			policy:       &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+			failures:     1,
+			code:         http.StatusServiceUnavailable,
+			streamed:     true,
+			wantErr:      &HTTPError{code: http.StatusServiceUnavailable},
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := MockFlaky{t: t, failures: tt.failures, code: tt.code, retryAfter: tt.retryAfter}
+
+			s := httptest.NewServer(&m)
+			defer s.Close()
+
+			opts := []Option{OptBaseURL(s.URL)}
+			if tt.policy != nil {
+				opts = append(opts, OptRetry(*tt.policy))
+			}
+
+			c, err := NewClient(opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if tt.streamed {
+				_, err = c.PKSAddStream(context.Background(), strings.NewReader("key"), nil)
+			} else {
+				err = c.PKSAdd(context.Background(), "key")
+			}
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if got, want := m.requests.Load(), tt.wantRequests; got != want {
+				t.Errorf("got %v requests, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	s := httptest.NewServer(&MockFlaky{t: t, failures: 1, code: http.StatusServiceUnavailable})
+	defer s.Close()
+
+	c, err := NewClient(OptBaseURL(s.URL), OptRetry(RetryPolicy{MaxAttempts: 2, MinBackoff: time.Hour}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.PKSLookup(ctx, nil, "search", OperationGet, false, false, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"Missing", "", 0, false},
+		{"Seconds", "120", 2 * time.Minute, true},
+		{"Negative", "-1", 0, false},
+		{"Date", now.Add(time.Minute).Format(http.TimeFormat), time.Minute, true},
+		{"PastDate", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"Invalid", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.value != "" {
+				h.Set("Retry-After", tt.value)
+			}
+
+			d, ok := parseRetryAfter(h, now)
+			if d != tt.want || ok != tt.wantOK {
+				t.Errorf("got %v, %v, want %v, %v", d, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRevocation is returned when a revocation certificate is invalid.
+var ErrInvalidRevocation = errors.New("invalid revocation certificate")
+
+// PublishRevocation publishes a key revocation to the Key Service. The revocation certificate may
+// be a standalone key revocation signature, such as that generated by GnuPG, or a key containing
+// a key revocation signature. It may be ASCII armored or binary. The context controls the lifetime
+// of the requests.
+//
+// Before the revocation is submitted, the revoked key is retrieved from the Key Service to ensure
+// it is known, and the revocation signature is verified using the revoked key. A standalone
+// revocation signature is submitted along with the revoked primary key, as required by key servers.
+//
+// If revocationCert does not contain a valid key revocation signature, or the revoked key cannot be
+// retrieved from the Key Service, an error wrapping ErrInvalidRevocation is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) PublishRevocation(ctx context.Context, revocationCert string) (err error) {
+	ctx, op := c.startOperation(ctx, "PublishRevocation")
+	defer func() { op.end(err) }()
+
+	data := []byte(revocationCert)
+	if len(data) > 0 && data[0]&0x80 == 0 {
+		b, err := dearmor(revocationCert)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRevocation, err)
+		}
+		data = b
+	}
+
+	pkts, err := readPackets(data)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRevocation, err)
+	}
+	if len(pkts) == 0 {
+		return fmt.Errorf("%w: no packets found", ErrInvalidRevocation)
+	}
+
+	var keyText string
+
+	switch pkts[0].tag {
+	case tagSignature:
+		if keyText, err = c.standaloneRevocation(ctx, pkts); err != nil {
+			return err
+		}
+
+	case tagPublicKey:
+		if err := c.checkRevokedKeys(ctx, pkts); err != nil {
+			return err
+		}
+		if keyText, err = armor(data, armorTypePublicKey); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+	default:
+		return fmt.Errorf("%w: unexpected packet tag %v", ErrInvalidRevocation, pkts[0].tag)
+	}
+
+	return c.PKSAdd(ctx, keyText)
+}
+
+// standaloneRevocation validates that pkts contain a single key revocation signature, retrieves the
+// revoked key from the Key Service, verifies the signature, and returns an ASCII armored keyring
+// containing the revoked primary key and the revocation signature.
+func (c *Client) standaloneRevocation(ctx context.Context, pkts []packet) (string, error) {
+	if len(pkts) != 1 {
+		return "", fmt.Errorf("%w: unexpected packets following signature", ErrInvalidRevocation)
+	}
+
+	sig, err := parseSignature(pkts[0])
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidRevocation, err)
+	}
+	if sig.sigType != sigTypeKeyRevocation {
+		return "", fmt.Errorf("%w: unexpected signature type %#x", ErrInvalidRevocation, sig.sigType)
+	}
+
+	issuer := sig.issuer()
+	if issuer == nil {
+		return "", fmt.Errorf("%w: signature does not identify issuer", ErrInvalidRevocation)
+	}
+
+	search := issuer
+	if !validSearch(search) {
+		search = keyID(issuer, 8)
+	}
+
+	kt, err := c.GetKey(ctx, search)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to retrieve revoked key: %w", ErrInvalidRevocation, err)
+	}
+
+	keys, err := parseKeyring([]byte(kt))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidRevocation, err)
+	}
+
+	for _, k := range keys {
+		fp := k.fingerprint()
+		if bytes.Equal(fp, issuer) || bytes.Equal(keyID(fp, len(issuer)), issuer) {
+			if err := verifyKeyRevocation(k[0], pkts[0]); err != nil {
+				return "", fmt.Errorf("%w: signature verification failed: %w", ErrInvalidRevocation, err)
+			}
+
+			text, err := transferableKey{k[0], pkts[0]}.armor()
+			if err != nil {
+				return "", fmt.Errorf("%w", err)
+			}
+			return text, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: revoked key not found", ErrInvalidRevocation)
+}
+
+// checkRevokedKeys validates that each key in pkts contains a valid key revocation signature, and
+// that each key is known to the Key Service.
+func (c *Client) checkRevokedKeys(ctx context.Context, pkts []packet) error {
+	keys, err := splitKeys(pkts)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRevocation, err)
+	}
+
+	for _, k := range keys {
+		if !k.revoked() {
+			return fmt.Errorf("%w: key %X is not revoked", ErrInvalidRevocation, k.fingerprint())
+		}
+
+		fp := k.fingerprint()
+		if !validSearch(fp) {
+			fp = keyID(fp, 8)
+		}
+
+		if _, err := c.GetKey(ctx, fp); err != nil {
+			return fmt.Errorf("%w: failed to retrieve revoked key: %w", ErrInvalidRevocation, err)
+		}
+	}
+
+	return nil
+}
+
+// revoked returns true if k contains a key revocation signature made by its primary key. Key
+// revocation signatures directly follow the primary key packet, as specified in section 10.1 of RFC
+// 9580.
+func (k transferableKey) revoked() bool {
+	for _, p := range k[1:] {
+		if p.tag != tagSignature {
+			break
+		}
+
+		if verifyKeyRevocation(k[0], p) == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// MockRevocation serves keys in response to lookup requests, and records submitted keys.
+type MockRevocation struct {
+	MockKeyServer
+
+	keyText string // Submitted key text.
+}
+
+func (m *MockRevocation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != pathPKSAdd {
+		m.MockKeyServer.ServeHTTP(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		m.t.Fatalf("failed to parse form: %v", err)
+	}
+	m.keyText = r.Form.Get("keytext")
+}
+
+func TestPublishRevocation(t *testing.T) {
+	alice := readTestKey(t, "alice.asc")
+	rev := readTestKey(t, "alice.rev")
+	revoked := readTestKey(t, "alice-revoked.asc")
+
+	revData, err := dearmor(rev)
+	if err != nil {
+		t.Fatalf("failed to dearmor: %v", err)
+	}
+
+	// Corrupt the revocation signatures, so that they fail verification.
+	badRev := bytes.Clone(revData)
+	badRev[len(badRev)-1] ^= 0xff
+
+	revokedData, err := dearmor(revoked)
+	if err != nil {
+		t.Fatalf("failed to dearmor: %v", err)
+	}
+	pkts, err := readPackets(revokedData)
+	if err != nil {
+		t.Fatalf("failed to read packets: %v", err)
+	}
+	badRevoked := bytes.Clone(revokedData)
+	badRevoked[len(pkts[0].raw)+len(pkts[1].raw)-1] ^= 0xff
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context //nolint:containedctx
+		keys        map[string]string
+		cert        string
+		wantErr     error
+		wantRevoked bool
+	}{
+		{
+			name:        "Standalone",
+			ctx:         context.Background(),
+			keys:        map[string]string{aliceFingerprint: alice},
+			cert:        rev,
+			wantRevoked: true,
+		},
+		{
+			name:        "StandaloneBinary",
+			ctx:         context.Background(),
+			keys:        map[string]string{aliceFingerprint: alice},
+			cert:        string(revData),
+			wantRevoked: true,
+		},
+		{
+			name:        "RevokedKey",
+			ctx:         context.Background(),
+			keys:        map[string]string{aliceFingerprint: alice},
+			cert:        revoked,
+			wantRevoked: true,
+		},
+		{
+			name:    "StandaloneBadSignature",
+			ctx:     context.Background(),
+			keys:    map[string]string{aliceFingerprint: alice},
+			cert:    string(badRev),
+			wantErr: ErrInvalidRevocation,
+		},
+		{
+			name:    "RevokedKeyBadSignature",
+			ctx:     context.Background(),
+			keys:    map[string]string{aliceFingerprint: alice},
+			cert:    string(badRevoked),
+			wantErr: ErrInvalidRevocation,
+		},
+		{
+			name:    "UnknownKey",
+			ctx:     context.Background(),
+			cert:    rev,
+			wantErr: &HTTPError{code: http.StatusNotFound},
+		},
+		{
+			name:    "UnknownRevokedKey",
+			ctx:     context.Background(),
+			cert:    revoked,
+			wantErr: ErrInvalidRevocation,
+		},
+		{
+			name:    "NotRevoked",
+			ctx:     context.Background(),
+			keys:    map[string]string{aliceFingerprint: alice},
+			cert:    alice,
+			wantErr: ErrInvalidRevocation,
+		},
+		{
+			name:    "GnuPGColon",
+			ctx:     context.Background(),
+			cert:    ":" + rev,
+			wantErr: ErrInvalidRevocation,
+		},
+		{
+			name:    "Empty",
+			ctx:     context.Background(),
+			wantErr: ErrInvalidRevocation,
+		},
+		{
+			name:    "ContextCanceled",
+			ctx:     cancelled,
+			keys:    map[string]string{aliceFingerprint: alice},
+			cert:    rev,
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := MockRevocation{MockKeyServer: MockKeyServer{t: t, keys: tt.keys}}
+
+			s := httptest.NewServer(&m)
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			err = c.PublishRevocation(tt.ctx, tt.cert)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				keys, err := parseKeyring([]byte(m.keyText))
+				if err != nil {
+					t.Fatalf("failed to parse submitted key: %v", err)
+				}
+				if got, want := len(keys), 1; got != want {
+					t.Fatalf("got %v keys, want %v", got, want)
+				}
+				if got, want := keys[0].revoked(), tt.wantRevoked; got != want {
+					t.Errorf("got revoked %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
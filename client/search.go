@@ -0,0 +1,241 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidIndex is returned when a machine readable index response cannot be parsed.
+var ErrInvalidIndex = errors.New("invalid index response")
+
+// UserIDSummary describes a user ID of a key, as reported by an index operation.
+type UserIDSummary struct {
+	// User ID string.
+	UserID string
+	// Creation time, or the zero time if not reported.
+	Created time.Time
+	// Expiration time, or the zero time if not reported.
+	Expires time.Time
+	// Whether the user ID is revoked.
+	Revoked bool
+	// Whether the user ID is disabled.
+	Disabled bool
+	// Whether the user ID is expired.
+	Expired bool
+}
+
+// KeySummary describes a key, as reported by an index operation.
+type KeySummary struct {
+	// Upper-case hexadecimal key ID or fingerprint, as reported by the Key Service.
+	KeyID string
+	// OpenPGP public key algorithm ID, or zero if not reported.
+	Algorithm int
+	// Key length in bits, or zero if not reported.
+	Bits int
+	// Creation time, or the zero time if not reported.
+	Created time.Time
+	// Expiration time, or the zero time if not reported.
+	Expires time.Time
+	// Whether the key is revoked.
+	Revoked bool
+	// Whether the key is disabled.
+	Disabled bool
+	// Whether the key is expired.
+	Expired bool
+	// User IDs associated with the key.
+	UserIDs []UserIDSummary
+}
+
+// SearchByEmail searches the Key Service for keys with a user ID containing the specified email
+// address, using an exact match. The context controls the lifetime of the request.
+//
+// Pagination is controlled by pd, as described by PKSLookup. If email is not a valid email
+// address, an error wrapping ErrInvalidSearch is returned. If no keys match, an error wrapping
+// ErrKeyNotFound is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) SearchByEmail(ctx context.Context, pd *PageDetails, email string) ([]KeySummary, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" {
+		return nil, fmt.Errorf("%w", ErrInvalidSearch)
+	}
+	return c.search(ctx, pd, addr.Address, true)
+}
+
+// SearchByName searches the Key Service for keys with a user ID containing the specified name.
+// The context controls the lifetime of the request.
+//
+// Pagination is controlled by pd, as described by PKSLookup. If no keys match, an error wrapping
+// ErrKeyNotFound is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) SearchByName(ctx context.Context, pd *PageDetails, name string) ([]KeySummary, error) {
+	return c.search(ctx, pd, strings.TrimSpace(name), false)
+}
+
+// SearchText searches the Key Service for keys matching the specified free text. Interpretation of
+// text is left to the Key Service. The context controls the lifetime of the request.
+//
+// Pagination is controlled by pd, as described by PKSLookup. If no keys match, an error wrapping
+// ErrKeyNotFound is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) SearchText(ctx context.Context, pd *PageDetails, text string) ([]KeySummary, error) {
+	return c.search(ctx, pd, text, false)
+}
+
+// search performs an index operation with machine readable output, and parses the result.
+func (c *Client) search(ctx context.Context, pd *PageDetails, search string, exact bool) ([]KeySummary, error) {
+	body, err := c.Lookup(ctx, LookupRequest{
+		Search:    search,
+		Operation: OperationIndex,
+		Options:   []LookupOption{OptionMachineReadable},
+		Exact:     exact,
+		Page:      pd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := parseIndex(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return keys, nil
+}
+
+// parseIndex parses machine readable index output, as specified in section 5.2 of the OpenPGP
+// HTTP Keyserver Protocol (HKP) specification.
+func parseIndex(body string) ([]KeySummary, error) {
+	var keys []KeySummary
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ":")
+
+		switch fields[0] {
+		case "info":
+			if len(fields) < 2 || fields[1] != "1" {
+				return nil, fmt.Errorf("%w: unsupported version", ErrInvalidIndex)
+			}
+
+		case "pub":
+			k, err := parseIndexPub(fields[1:])
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k)
+
+		case "uid":
+			if len(keys) == 0 {
+				return nil, fmt.Errorf("%w: uid precedes pub", ErrInvalidIndex)
+			}
+
+			uid, err := parseIndexUID(fields[1:])
+			if err != nil {
+				return nil, err
+			}
+			keys[len(keys)-1].UserIDs = append(keys[len(keys)-1].UserIDs, uid)
+		}
+	}
+
+	return keys, nil
+}
+
+// indexField returns the i-th field of fields, or an empty string if there is no such field.
+func indexField(fields []string, i int) string {
+	if i < len(fields) {
+		return fields[i]
+	}
+	return ""
+}
+
+// parseIndexPub parses the fields of a "pub" line.
+func parseIndexPub(fields []string) (KeySummary, error) {
+	k := KeySummary{KeyID: strings.ToUpper(indexField(fields, 0))}
+	if k.KeyID == "" {
+		return KeySummary{}, fmt.Errorf("%w: missing key ID", ErrInvalidIndex)
+	}
+
+	var err error
+	if k.Algorithm, err = parseIndexInt(indexField(fields, 1)); err != nil {
+		return KeySummary{}, err
+	}
+	if k.Bits, err = parseIndexInt(indexField(fields, 2)); err != nil {
+		return KeySummary{}, err
+	}
+	if k.Created, err = parseIndexTime(indexField(fields, 3)); err != nil {
+		return KeySummary{}, err
+	}
+	if k.Expires, err = parseIndexTime(indexField(fields, 4)); err != nil {
+		return KeySummary{}, err
+	}
+	k.Revoked, k.Disabled, k.Expired = parseIndexFlags(indexField(fields, 5))
+
+	return k, nil
+}
+
+// parseIndexUID parses the fields of a "uid" line.
+func parseIndexUID(fields []string) (UserIDSummary, error) {
+	s, err := url.PathUnescape(indexField(fields, 0))
+	if err != nil {
+		return UserIDSummary{}, fmt.Errorf("%w: %w", ErrInvalidIndex, err)
+	}
+	uid := UserIDSummary{UserID: s}
+
+	if uid.Created, err = parseIndexTime(indexField(fields, 1)); err != nil {
+		return UserIDSummary{}, err
+	}
+	if uid.Expires, err = parseIndexTime(indexField(fields, 2)); err != nil {
+		return UserIDSummary{}, err
+	}
+	uid.Revoked, uid.Disabled, uid.Expired = parseIndexFlags(indexField(fields, 3))
+
+	return uid, nil
+}
+
+// parseIndexInt parses an optional integer field.
+func parseIndexInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidIndex, err)
+	}
+	return n, nil
+}
+
+// parseIndexTime parses an optional time field, expressed in seconds since the epoch.
+func parseIndexTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidIndex, err)
+	}
+	return time.Unix(n, 0).UTC(), nil
+}
+
+// parseIndexFlags parses a flags field.
+func parseIndexFlags(s string) (revoked, disabled, expired bool) {
+	return strings.Contains(s, "r"), strings.Contains(s, "d"), strings.Contains(s, "e")
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testIndex = "info:1:2\r\n" +
+	"pub:6927a60652a6966a58bb936819915ccf6f9f5b7a:22:255:1760788800::\r\n" +
+	"uid:Alice%20%3Calice%40example.com%3E:1760788800::\r\n" +
+	"uid:Alice%3A%20Caf%C3%A9:1760788800:1792324800:r\r\n" +
+	"pub:58A2E029D2B4302C:1:4096:1760788800:1760875200:re\r\n"
+
+func TestParseIndex(t *testing.T) {
+	created := time.Unix(1760788800, 0).UTC()
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+		want    []KeySummary
+	}{
+		{
+			name: "OK",
+			body: testIndex,
+			want: []KeySummary{
+				{
+					KeyID:     aliceFingerprint,
+					Algorithm: 22,
+					Bits:      255,
+					Created:   created,
+					UserIDs: []UserIDSummary{
+						{UserID: "Alice <alice@example.com>", Created: created},
+						{
+							UserID:  "Alice: Café",
+							Created: created,
+							Expires: time.Unix(1792324800, 0).UTC(),
+							Revoked: true,
+						},
+					},
+				},
+				{
+					KeyID:     "58A2E029D2B4302C",
+					Algorithm: 1,
+					Bits:      4096,
+					Created:   created,
+					Expires:   time.Unix(1760875200, 0).UTC(),
+					Revoked:   true,
+					Expired:   true,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			body: "info:1:0\n",
+		},
+		{
+			name:    "UnsupportedVersion",
+			body:    "info:2:0\n",
+			wantErr: ErrInvalidIndex,
+		},
+		{
+			name:    "OrphanUID",
+			body:    "uid:Alice:::\n",
+			wantErr: ErrInvalidIndex,
+		},
+		{
+			name:    "MissingKeyID",
+			body:    "pub::1:4096:::\n",
+			wantErr: ErrInvalidIndex,
+		},
+		{
+			name:    "InvalidTime",
+			body:    "pub:58A2E029D2B4302C:1:4096:blah::\n",
+			wantErr: ErrInvalidIndex,
+		},
+		{
+			name:    "InvalidEscape",
+			body:    "pub:58A2E029D2B4302C:1:4096:::\nuid:%zz:::\n",
+			wantErr: ErrInvalidIndex,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseIndex(tt.body)
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if got, want := keys, tt.want; !reflect.DeepEqual(got, want) {
+				t.Errorf("got keys %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+type MockSearch struct {
+	t          *testing.T
+	code       int
+	wantSearch string
+	wantExact  bool
+}
+
+func (m *MockSearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.URL.Path, pathPKSLookup; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	q := r.URL.Query()
+	if got, want := q.Get("search"), m.wantSearch; got != want {
+		m.t.Errorf("got search %v, want %v", got, want)
+	}
+	if got, want := q.Get("op"), OperationIndex; got != want {
+		m.t.Errorf("got op %v, want %v", got, want)
+	}
+	if got, want := q.Get("options"), OptionMachineReadable; got != want {
+		m.t.Errorf("got options %v, want %v", got, want)
+	}
+	if got, want := q.Get("exact") == "on", m.wantExact; got != want {
+		m.t.Errorf("got exact %v, want %v", got, want)
+	}
+	if got, want := q.Get("x-pagesize"), "10"; got != want {
+		m.t.Errorf("got page size %v, want %v", got, want)
+	}
+
+	w.Header().Set("X-HKP-Next-Page-Token", "next")
+	w.WriteHeader(m.code)
+	if _, err := io.WriteString(w, testIndex); err != nil {
+		m.t.Fatalf("failed to write response: %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name       string
+		search     func(*Client, context.Context, *PageDetails, string) ([]KeySummary, error)
+		query      string
+		code       int
+		wantSearch string
+		wantExact  bool
+		wantErr    error
+	}{
+		{
+			name:       "Email",
+			search:     (*Client).SearchByEmail,
+			query:      "alice@example.com",
+			code:       http.StatusOK,
+			wantSearch: "alice@example.com",
+			wantExact:  true,
+		},
+		{
+			name:    "EmailWithName",
+			search:  (*Client).SearchByEmail,
+			query:   "Alice <alice@example.com>",
+			wantErr: ErrInvalidSearch,
+		},
+		{
+			name:    "EmailInvalid",
+			search:  (*Client).SearchByEmail,
+			query:   "alice",
+			wantErr: ErrInvalidSearch,
+		},
+		{
+			name:       "Name",
+			search:     (*Client).SearchByName,
+			query:      " Alice ",
+			code:       http.StatusOK,
+			wantSearch: "Alice",
+		},
+		{
+			name:    "NameEmpty",
+			search:  (*Client).SearchByName,
+			query:   " ",
+			wantErr: ErrInvalidSearch,
+		},
+		{
+			name:       "Text",
+			search:     (*Client).SearchText,
+			query:      "alice example",
+			code:       http.StatusOK,
+			wantSearch: "alice example",
+		},
+		{
+			name:       "HTTPError",
+			search:     (*Client).SearchText,
+			query:      "alice",
+			code:       http.StatusNotFound,
+			wantSearch: "alice",
+			wantErr:    &HTTPError{code: http.StatusNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockSearch{
+				t:          t,
+				code:       tt.code,
+				wantSearch: tt.wantSearch,
+				wantExact:  tt.wantExact,
+			})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			pd := PageDetails{Size: 10}
+
+			keys, err := tt.search(c, context.Background(), &pd, tt.query)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if got, want := len(keys), 2; got != want {
+					t.Errorf("got %v keys, want %v", got, want)
+				}
+
+				if got, want := pd.Token, "next"; got != want {
+					t.Errorf("got page token %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
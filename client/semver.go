@@ -0,0 +1,184 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is returned when a version or version constraint cannot be parsed.
+var ErrInvalidVersion = errors.New("invalid version")
+
+// ErrVersionUnsatisfied is returned when the Key Service version does not satisfy a constraint.
+var ErrVersionUnsatisfied = errors.New("version constraint not satisfied")
+
+// semver describes a semantic version, as specified by https://semver.org/.
+type semver struct {
+	major, minor, patch int
+	pre                 []string // Pre-release identifiers.
+}
+
+// parseSemver parses s as a semantic version. A leading "v" is permitted, and omitted minor and
+// patch versions are treated as zero. Build metadata is ignored.
+func parseSemver(s string) (semver, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	v, _, _ = strings.Cut(v, "+")
+
+	var sv semver
+
+	v, pre, hasPre := strings.Cut(v, "-")
+	if hasPre {
+		if pre == "" {
+			return semver{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		sv.pre = strings.Split(pre, ".")
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return semver{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+
+	nums := []*int{&sv.major, &sv.minor, &sv.patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		*nums[i] = n
+	}
+
+	return sv, nil
+}
+
+// compare returns -1, 0 or +1 depending on whether v is less than, equal to, or greater than w,
+// according to the precedence rules of semantic versioning.
+func (v semver) compare(w semver) int {
+	for _, d := range []int{v.major - w.major, v.minor - w.minor, v.patch - w.patch} {
+		if d < 0 {
+			return -1
+		} else if d > 0 {
+			return 1
+		}
+	}
+
+	// A version without pre-release identifiers has higher precedence.
+	switch {
+	case len(v.pre) == 0 && len(w.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(w.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.pre) && i < len(w.pre); i++ {
+		if c := comparePrerelease(v.pre[i], w.pre[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(v.pre) < len(w.pre):
+		return -1
+	case len(v.pre) > len(w.pre):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares pre-release identifiers a and b. Numeric identifiers are compared
+// numerically, and have lower precedence than alphanumeric identifiers.
+func comparePrerelease(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		default:
+			return 0
+		}
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// CompareVersions returns -1, 0 or +1 depending on whether semantic version a is less than, equal
+// to, or greater than semantic version b. If either version cannot be parsed, an error wrapping
+// ErrInvalidVersion is returned.
+func CompareVersions(a, b string) (int, error) {
+	va, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+
+	vb, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return va.compare(vb), nil
+}
+
+// versionConstraintOps maps constraint operators to a function that reports whether the result of
+// a version comparison satisfies the operator. Longer operators precede their prefixes.
+var versionConstraintOps = []struct {
+	op string
+	ok func(int) bool
+}{
+	{">=", func(c int) bool { return c >= 0 }},
+	{"<=", func(c int) bool { return c <= 0 }},
+	{"!=", func(c int) bool { return c != 0 }},
+	{">", func(c int) bool { return c > 0 }},
+	{"<", func(c int) bool { return c < 0 }},
+	{"=", func(c int) bool { return c == 0 }},
+}
+
+// satisfiesConstraint reports whether version satisfies constraint. A constraint consists of one
+// or more comma-separated comparisons, all of which must be satisfied, such as ">= 1.2, < 2". A
+// comparison without an operator requires an exact match.
+func satisfiesConstraint(version, constraint string) (bool, error) {
+	v, err := parseSemver(version)
+	if err != nil {
+		return false, err
+	}
+
+	for _, term := range strings.Split(constraint, ",") {
+		term = strings.TrimSpace(term)
+
+		ok := func(c int) bool { return c == 0 }
+		for _, o := range versionConstraintOps {
+			if strings.HasPrefix(term, o.op) {
+				term, ok = term[len(o.op):], o.ok
+				break
+			}
+		}
+
+		w, err := parseSemver(term)
+		if err != nil {
+			return false, fmt.Errorf("%w: constraint %q", ErrInvalidVersion, constraint)
+		}
+
+		if !ok(v.compare(w)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
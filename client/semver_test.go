@@ -0,0 +1,84 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		wantErr error
+		want    int
+	}{
+		{"InvalidA", "x", "1.0.0", ErrInvalidVersion, 0},
+		{"InvalidB", "1.0.0", "1.0.0.0", ErrInvalidVersion, 0},
+		{"EmptyPrerelease", "1.0.0-", "1.0.0", ErrInvalidVersion, 0},
+		{"Equal", "1.2.3", "v1.2.3", nil, 0},
+		{"ImpliedPatch", "1.2", "1.2.0", nil, 0},
+		{"BuildMetadata", "1.2.3+abc", "1.2.3+def", nil, 0},
+		{"Major", "2.0.0", "1.9.9", nil, 1},
+		{"Minor", "1.1.0", "1.2.0", nil, -1},
+		{"Patch", "1.2.4", "1.2.3", nil, 1},
+		{"Prerelease", "1.0.0-rc.1", "1.0.0", nil, -1},
+		{"PrereleaseNumeric", "1.0.0-rc.2", "1.0.0-rc.10", nil, -1},
+		{"PrereleaseAlpha", "1.0.0-alpha", "1.0.0-beta", nil, -1},
+		{"PrereleaseNumericAlpha", "1.0.0-1", "1.0.0-alpha", nil, -1},
+		{"PrereleaseLength", "1.0.0-alpha.1", "1.0.0-alpha", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompareVersions(tt.a, tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSatisfiesConstraint(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		constraint string
+		wantErr    error
+		want       bool
+	}{
+		{"InvalidVersion", "x", ">= 1.0", ErrInvalidVersion, false},
+		{"InvalidConstraint", "1.0.0", ">= x", ErrInvalidVersion, false},
+		{"Exact", "1.2.3", "1.2.3", nil, true},
+		{"ExactMismatch", "1.2.3", "= 1.2.4", nil, false},
+		{"NotEqual", "1.2.3", "!= 1.2.4", nil, true},
+		{"GreaterOrEqual", "1.2.3", ">=1.2", nil, true},
+		{"Greater", "1.2.3", "> 1.2.3", nil, false},
+		{"LessOrEqual", "1.2.3", "<= 1.2.3", nil, true},
+		{"Less", "1.2.3", "< 1.2.3", nil, false},
+		{"Range", "1.5.0", ">= 1.2, < 2", nil, true},
+		{"RangeOutside", "2.0.0", ">= 1.2, < 2", nil, false},
+		{"PrereleaseBelowRelease", "2.0.0-rc.1", "< 2", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := satisfiesConstraint(tt.version, tt.constraint)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
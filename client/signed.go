@@ -0,0 +1,110 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const pathPKSReplace = "/pks/replace"
+
+// ErrInvalidSigner is returned when a signer is invalid.
+var ErrInvalidSigner = errors.New("invalid signer")
+
+// Signer creates signatures on behalf of the caller, so that private key material is not handled
+// by this package.
+type Signer interface {
+	// Sign returns an ASCII armored, detached OpenPGP signature over data.
+	Sign(ctx context.Context, data []byte) (string, error)
+}
+
+// SignerFunc is an adapter to allow the use of an ordinary function as a Signer.
+type SignerFunc func(ctx context.Context, data []byte) (string, error)
+
+// Sign calls f(ctx, data).
+func (f SignerFunc) Sign(ctx context.Context, data []byte) (string, error) {
+	return f(ctx, data)
+}
+
+// PKSDelete requests that the keys in keyText be removed from the Key Service, using the signed
+// "/pks/delete" endpoint supported by Hockeypuck. The request is authorized by a detached signature
+// over keyText, created by s using a key the server is configured to accept. The context controls
+// the lifetime of the request.
+//
+// The request is a form-encoded POST, unlike the bearer token authorized DELETE request made to the
+// same path by DeleteKey, which is supported by the Sylabs Key Service.
+//
+// If the Key Service does not support signed deletion, an error wrapping ErrUnsupportedOperation
+// is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) PKSDelete(ctx context.Context, keyText string, s Signer) (err error) {
+	ctx, op := c.startOperation(ctx, "PKSDelete")
+	defer func() { op.end(err) }()
+
+	return c.signedKeyRequest(ctx, pathPKSDelete, keyText, s)
+}
+
+// PKSReplace requests that the keys in keyText replace the corresponding keys held by the Key
+// Service, using the signed "/pks/replace" endpoint supported by Hockeypuck. Unlike PKSAdd, the
+// submitted keys are not merged with existing keys. The request is authorized by a detached
+// signature over keyText, created by s using a key the server is configured to accept. The context
+// controls the lifetime of the request.
+//
+// If the Key Service does not support signed replacement, an error wrapping
+// ErrUnsupportedOperation is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) PKSReplace(ctx context.Context, keyText string, s Signer) (err error) {
+	ctx, op := c.startOperation(ctx, "PKSReplace")
+	defer func() { op.end(err) }()
+
+	return c.signedKeyRequest(ctx, pathPKSReplace, keyText, s)
+}
+
+// signedKeyRequest submits keyText to the endpoint at path, along with a detached signature over
+// keyText created by s.
+func (c *Client) signedKeyRequest(ctx context.Context, path, keyText string, s Signer) error {
+	if keyText == "" {
+		return fmt.Errorf("%w", ErrInvalidKeyText)
+	}
+	if s == nil {
+		return fmt.Errorf("%w", ErrInvalidSigner)
+	}
+
+	sig, err := s.Sign(ctx, []byte(keyText))
+	if err != nil {
+		return fmt.Errorf("failed to sign key text: %w", err)
+	}
+
+	ref := &url.URL{Path: path}
+
+	v := url.Values{}
+	v.Set("keytext", keyText)
+	v.Set("keysig", sig)
+
+	req, err := c.NewRequest(ctx, http.MethodPost, ref, strings.NewReader(v.Encode()))
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	req.Header.Set("Content-Type", contentTypeForm)
+
+	res, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 { // non-2xx status code
+		return fmt.Errorf("%w", c.operationErrorFromResponse(res))
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockSignedKeyRequest struct {
+	t        *testing.T
+	code     int
+	wantPath string
+	keyText  string
+	keySig   string
+}
+
+func (m *MockSignedKeyRequest) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.Method, http.MethodPost; got != want {
+		m.t.Errorf("got method %v, want %v", got, want)
+	}
+
+	if got, want := r.URL.Path, m.wantPath; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	if got, want := r.Header.Get("Content-Type"), contentTypeForm; got != want {
+		m.t.Errorf("got content type %v, want %v", got, want)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		m.t.Fatalf("failed to parse form: %v", err)
+	}
+	if got, want := r.Form.Get("keytext"), m.keyText; got != want {
+		m.t.Errorf("got key text %v, want %v", got, want)
+	}
+	if got, want := r.Form.Get("keysig"), m.keySig; got != want {
+		m.t.Errorf("got key signature %v, want %v", got, want)
+	}
+
+	w.WriteHeader(m.code)
+}
+
+func TestSignedKeyRequest(t *testing.T) {
+	errSign := errors.New("sign failed")
+
+	signer := SignerFunc(func(_ context.Context, data []byte) (string, error) {
+		return "sig:" + string(data), nil
+	})
+	badSigner := SignerFunc(func(context.Context, []byte) (string, error) {
+		return "", errSign
+	})
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context //nolint:containedctx
+		keyText string
+		signer  Signer
+		code    int
+		wantErr error
+	}{
+		{
+			name:    "OK",
+			ctx:     context.Background(),
+			keyText: "key",
+			signer:  signer,
+			code:    http.StatusOK,
+		},
+		{
+			name:    "HTTPError",
+			ctx:     context.Background(),
+			keyText: "key",
+			signer:  signer,
+			code:    http.StatusForbidden,
+			wantErr: &HTTPError{code: http.StatusForbidden},
+		},
+		{
+			name:    "Unsupported",
+			ctx:     context.Background(),
+			keyText: "key",
+			signer:  signer,
+			code:    http.StatusMethodNotAllowed,
+			wantErr: ErrUnsupportedOperation,
+		},
+		{
+			name:    "ContextCanceled",
+			ctx:     cancelled,
+			keyText: "key",
+			signer:  signer,
+			code:    http.StatusOK,
+			wantErr: context.Canceled,
+		},
+		{
+			name:    "SignError",
+			ctx:     context.Background(),
+			keyText: "key",
+			signer:  badSigner,
+			wantErr: errSign,
+		},
+		{
+			name:    "NilSigner",
+			ctx:     context.Background(),
+			keyText: "key",
+			wantErr: ErrInvalidSigner,
+		},
+		{
+			name:    "InvalidKeyText",
+			ctx:     context.Background(),
+			signer:  signer,
+			wantErr: ErrInvalidKeyText,
+		},
+	}
+
+	ops := []struct {
+		name string
+		path string
+		fn   func(*Client, context.Context, string, Signer) error
+	}{
+		{"Delete", pathPKSDelete, (*Client).PKSDelete},
+		{"Replace", pathPKSReplace, (*Client).PKSReplace},
+	}
+
+	for _, op := range ops {
+		for _, tt := range tests {
+			op, tt := op, tt
+			t.Run(op.name+tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				s := httptest.NewServer(&MockSignedKeyRequest{
+					t:        t,
+					code:     tt.code,
+					wantPath: op.path,
+					keyText:  tt.keyText,
+					keySig:   "sig:" + tt.keyText,
+				})
+				defer s.Close()
+
+				c, err := NewClient(OptBaseURL(s.URL))
+				if err != nil {
+					t.Fatalf("failed to create client: %v", err)
+				}
+
+				err = op.fn(c, tt.ctx, tt.keyText, tt.signer)
+
+				if got, want := err, tt.wantErr; !errors.Is(got, want) {
+					t.Fatalf("got error %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
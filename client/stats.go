@@ -0,0 +1,176 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidStats is returned when the response to a stats request cannot be parsed.
+var ErrInvalidStats = errors.New("invalid stats response")
+
+// StatsPeer describes a peer of the Key Service.
+type StatsPeer struct {
+	// Name of the peer, or its address if the name is not reported.
+	Name string
+	// HTTP address of the peer, if reported.
+	HTTPAddr string
+	// Reconciliation address of the peer, if reported.
+	ReconAddr string
+}
+
+// ServerStats describes statistics reported by the Key Service.
+type ServerStats struct {
+	// Name of the key server software, if reported.
+	Software string
+	// Version of the key server software, if reported.
+	Version string
+	// Hostname of the key server, if reported.
+	Hostname string
+	// Total number of keys held by the key server.
+	TotalKeys int
+	// Peers the key server synchronizes with.
+	Peers []StatsPeer
+}
+
+// Stats requests statistics from the Key Service using the "stats" operation. Both the JSON output
+// of Hockeypuck and the HTML output of SKS are supported. The context controls the lifetime of the
+// request.
+//
+// If the response cannot be parsed, an error wrapping ErrInvalidStats is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) Stats(ctx context.Context) (ss *ServerStats, err error) {
+	ctx, op := c.startOperation(ctx, "Stats")
+	defer func() { op.end(err) }()
+
+	body, err := c.Lookup(ctx, LookupRequest{
+		Operation: OperationStats,
+		Options:   []LookupOption{OptionMachineReadable},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	ss, err = parseStats(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return ss, nil
+}
+
+// statsResponse is the machine readable response to a stats request, as returned by Hockeypuck.
+type statsResponse struct {
+	Software string `json:"software"`
+	Version  string `json:"version"`
+	Hostname string `json:"hostname"`
+	NumKeys  int    `json:"numkeys"`
+	Total    int    `json:"total"`
+	Peers    []struct {
+		Name      string `json:"name"`
+		HTTPAddr  string `json:"httpAddr"`
+		ReconAddr string `json:"reconAddr"`
+	} `json:"peers"`
+}
+
+// parseStats parses the body of a response to a stats request.
+func parseStats(body string) (*ServerStats, error) {
+	if b := strings.TrimSpace(body); strings.HasPrefix(b, "{") {
+		return parseStatsJSON(b)
+	}
+	return parseStatsHTML(body)
+}
+
+// parseStatsJSON parses a machine readable stats response.
+func parseStatsJSON(body string) (*ServerStats, error) {
+	var sr statsResponse
+	if err := json.Unmarshal([]byte(body), &sr); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStats, err)
+	}
+
+	ss := ServerStats{
+		Software:  sr.Software,
+		Version:   sr.Version,
+		Hostname:  sr.Hostname,
+		TotalKeys: sr.NumKeys,
+	}
+	if ss.TotalKeys == 0 {
+		ss.TotalKeys = sr.Total
+	}
+
+	for _, p := range sr.Peers {
+		sp := StatsPeer{Name: p.Name, HTTPAddr: p.HTTPAddr, ReconAddr: p.ReconAddr}
+		if sp.Name == "" {
+			sp.Name = p.ReconAddr
+		}
+		ss.Peers = append(ss.Peers, sp)
+	}
+
+	return &ss, nil
+}
+
+var (
+	// reStatsSetting matches a setting row of an HTML stats table.
+	reStatsSetting = regexp.MustCompile(`(?is)<td[^>]*>\s*([^<:]+):\s*</td>\s*<td[^>]*>(.*?)</td>`)
+	// reStatsTotal matches the total number of keys in an HTML stats page.
+	reStatsTotal = regexp.MustCompile(`(?i)total number of keys:\s*([0-9,]+)`)
+	// reStatsPeers matches the gossip peers table of an HTML stats page.
+	reStatsPeers = regexp.MustCompile(`(?is)<h2>\s*gossip peers\s*</h2>\s*<table[^>]*>(.*?)</table>`)
+	// reStatsCell matches a table cell.
+	reStatsCell = regexp.MustCompile(`(?is)<td[^>]*>(.*?)</td>`)
+	// reStatsSoftware matches a software name in the title of an HTML stats page.
+	reStatsSoftware = regexp.MustCompile(`(?is)<title>\s*(\S+)`)
+)
+
+// parseStatsHTML parses a human readable stats response, as returned by SKS.
+func parseStatsHTML(body string) (*ServerStats, error) {
+	m := reStatsTotal.FindStringSubmatch(body)
+	if m == nil {
+		return nil, fmt.Errorf("%w: total number of keys not found", ErrInvalidStats)
+	}
+
+	total, err := strconv.Atoi(strings.ReplaceAll(m[1], ",", ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStats, err)
+	}
+
+	ss := ServerStats{TotalKeys: total}
+
+	for _, m := range reStatsSetting.FindAllStringSubmatch(body, -1) {
+		value := htmlText(m[2])
+
+		switch strings.ToLower(strings.TrimSpace(m[1])) {
+		case "software":
+			ss.Software = value
+		case "version":
+			ss.Version = value
+		case "hostname":
+			ss.Hostname = value
+		}
+	}
+
+	if ss.Software == "" {
+		if m := reStatsSoftware.FindStringSubmatch(body); m != nil {
+			ss.Software = htmlText(m[1])
+		}
+	}
+
+	if m := reStatsPeers.FindStringSubmatch(body); m != nil {
+		for _, cell := range reStatsCell.FindAllStringSubmatch(m[1], -1) {
+			if addr := htmlText(cell[1]); addr != "" {
+				ss.Peers = append(ss.Peers, StatsPeer{Name: addr, ReconAddr: addr})
+			}
+		}
+	}
+
+	return &ss, nil
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const hockeypuckStats = `{
+  "timestamp": "2026-10-18T12:00:00Z",
+  "hostname": "keys.example.com",
+  "nodename": "node1",
+  "httpAddr": ":11371",
+  "reconAddr": ":11370",
+  "software": "Hockeypuck",
+  "version": "2.2.0",
+  "peers": [
+    {"name": "peer1", "httpAddr": "peer1.example.com:11371", "reconAddr": "peer1.example.com:11370"},
+    {"httpAddr": "peer2.example.com:11371", "reconAddr": "peer2.example.com:11370"}
+  ],
+  "numkeys": 1234567
+}`
+
+const sksStats = `<html><head><title>SKS OpenPGP Keyserver statistics</title></head><body>
+<h1>SKS OpenPGP Keyserver statistics</h1>Taken at 2026-10-18 12:00:00 UTC<p>
+<h2>Settings</h2>
+<table summary="Keyserver Settings">
+<tr><td>Hostname:</td><td>sks.example.com</td></tr>
+<tr><td>Nodename:</td><td>node1</td></tr>
+<tr><td>Version:</td><td>1.1.6</td></tr>
+<tr><td>Server contact:</td><td>0x0123456789ABCDEF</td></tr>
+</table>
+<h2>Gossip Peers</h2>
+<table summary="Gossip Peers">
+<tr><td>peer1.example.com 11370</td></tr>
+<tr><td>peer2.example.com 11370</td></tr>
+</table>
+<h2>Outgoing Mailsync Peers</h2>
+<table summary="Mailsync Peers"><tr><td>pgp-public-keys@example.com</td></tr></table>
+<h2>Statistics</h2><p>Total number of keys: 5,678,901</p>
+</body></html>`
+
+func TestParseStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+		want    *ServerStats
+	}{
+		{
+			name: "Hockeypuck",
+			body: hockeypuckStats,
+			want: &ServerStats{
+				Software:  "Hockeypuck",
+				Version:   "2.2.0",
+				Hostname:  "keys.example.com",
+				TotalKeys: 1234567,
+				Peers: []StatsPeer{
+					{"peer1", "peer1.example.com:11371", "peer1.example.com:11370"},
+					{"peer2.example.com:11370", "peer2.example.com:11371", "peer2.example.com:11370"},
+				},
+			},
+		},
+		{
+			name: "HockeypuckTotal",
+			body: `{"software":"Hockeypuck","version":"2.1.0","total":42}`,
+			want: &ServerStats{Software: "Hockeypuck", Version: "2.1.0", TotalKeys: 42},
+		},
+		{
+			name: "SKS",
+			body: sksStats,
+			want: &ServerStats{
+				Software:  "SKS",
+				Version:   "1.1.6",
+				Hostname:  "sks.example.com",
+				TotalKeys: 5678901,
+				Peers: []StatsPeer{
+					{Name: "peer1.example.com 11370", ReconAddr: "peer1.example.com 11370"},
+					{Name: "peer2.example.com 11370", ReconAddr: "peer2.example.com 11370"},
+				},
+			},
+		},
+		{
+			name:    "MalformedJSON",
+			body:    "{",
+			wantErr: ErrInvalidStats,
+		},
+		{
+			name:    "NoTotal",
+			body:    "<html></html>",
+			wantErr: ErrInvalidStats,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss, err := parseStats(tt.body)
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if got, want := ss, tt.want; !reflect.DeepEqual(got, want) {
+					t.Errorf("got stats %+v, want %+v", got, want)
+				}
+			}
+		})
+	}
+}
+
+type MockStats struct {
+	t        *testing.T
+	code     int
+	response string
+}
+
+func (m *MockStats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.URL.Path, pathPKSLookup; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	q := r.URL.Query()
+	if got, want := q.Get("op"), OperationStats; got != want {
+		m.t.Errorf("got op %v, want %v", got, want)
+	}
+	if got, want := q.Get("options"), OptionMachineReadable; got != want {
+		m.t.Errorf("got options %v, want %v", got, want)
+	}
+	if _, ok := q["search"]; ok {
+		m.t.Error("unexpected search")
+	}
+
+	w.WriteHeader(m.code)
+	if _, err := io.WriteString(w, m.response); err != nil {
+		m.t.Fatalf("failed to write response: %v", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name          string
+		ctx           context.Context //nolint:containedctx
+		code          int
+		response      string
+		wantErr       error
+		wantTotalKeys int
+	}{
+		{
+			name:          "OK",
+			ctx:           context.Background(),
+			code:          http.StatusOK,
+			response:      hockeypuckStats,
+			wantTotalKeys: 1234567,
+		},
+		{
+			name:    "HTTPError",
+			ctx:     context.Background(),
+			code:    http.StatusNotImplemented,
+			wantErr: &HTTPError{code: http.StatusNotImplemented},
+		},
+		{
+			name:    "ContextCanceled",
+			ctx:     cancelled,
+			code:    http.StatusOK,
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockStats{t: t, code: tt.code, response: tt.response})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			ss, err := c.Stats(tt.ctx)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if got, want := ss.TotalKeys, tt.wantTotalKeys; got != want {
+					t.Errorf("got total keys %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
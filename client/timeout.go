@@ -0,0 +1,280 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTimeout is returned when a timeout is invalid.
+var ErrInvalidTimeout = errors.New("invalid timeout")
+
+// TimeoutPhase identifies the phase of a request to which a timeout applies.
+type TimeoutPhase string
+
+const (
+	// TimeoutConnect is the phase in which a connection to the Key Service is established.
+	TimeoutConnect TimeoutPhase = "connect"
+	// TimeoutTLSHandshake is the phase in which the TLS handshake is performed.
+	TimeoutTLSHandshake TimeoutPhase = "TLS handshake"
+	// TimeoutFirstByte is the phase between the request being written and the first byte of the
+	// response being received.
+	TimeoutFirstByte TimeoutPhase = "time to first byte"
+	// TimeoutTotal is the entire request, including retries and reading the response body.
+	TimeoutTotal TimeoutPhase = "total"
+)
+
+const (
+	defaultConnectTimeout      = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+	defaultFirstByteTimeout    = 60 * time.Second
+)
+
+// defaultTimeouts are the timeouts applied to requests if OptTimeouts is not used. No total timeout
+// is applied by default, since large keyrings may take arbitrarily long to transfer.
+var defaultTimeouts = Timeouts{
+	Connect:      defaultConnectTimeout,
+	TLSHandshake: defaultTLSHandshakeTimeout,
+	FirstByte:    defaultFirstByteTimeout,
+}
+
+// TimeoutError is returned when a request exceeds a default timeout configured with OptTimeouts.
+// It matches context.DeadlineExceeded when tested with errors.Is.
+type TimeoutError struct {
+	// Phase of the request that timed out.
+	Phase TimeoutPhase
+	// Timeout that was exceeded.
+	Duration time.Duration
+}
+
+// Error returns a human-readable representation of e.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timeout of %v exceeded", e.Phase, e.Duration)
+}
+
+// Timeout returns true, to indicate e is a timeout.
+func (e *TimeoutError) Timeout() bool { return true }
+
+// Is returns true if target is context.DeadlineExceeded.
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded //nolint:errorlint
+}
+
+// Timeouts describes default timeouts applied to requests. A zero value disables the corresponding
+// timeout.
+type Timeouts struct {
+	// Maximum time to establish a connection to the Key Service.
+	Connect time.Duration
+	// Maximum time to perform a TLS handshake with the Key Service.
+	TLSHandshake time.Duration
+	// Maximum time between writing a request and receiving the first byte of the response.
+	FirstByte time.Duration
+	// Maximum time for a request, including retries and reading the response body. Operations that
+	// make several requests apply it to each request separately.
+	Total time.Duration
+}
+
+// OptTimeouts sets default timeouts for each request sent to the Key Service, replacing the
+// defaults. The connect, TLS handshake and first byte timeouts apply to each attempt, and the total
+// timeout applies to each request as a whole, including retries and reading the response body. The
+// total timeout is not shared by the requests made by an operation, such as the lookup and upload
+// made by PublishRevocation.
+//
+// If OptTimeouts is not used, the connect timeout is 30 seconds, the TLS handshake timeout is 10
+// seconds, the first byte timeout is 60 seconds, and no total timeout is applied.
+//
+// A timeout is only applied if the context passed by the caller does not have an earlier
+// deadline. If a timeout is exceeded, an error wrapping a TimeoutError identifying the phase of the
+// request is returned. Attempts that exceed the connect, TLS handshake or first byte timeouts are
+// retried according to the policy configured with OptRetry.
+func OptTimeouts(t Timeouts) Option {
+	return func(co *clientOptions) error {
+		if t.Connect < 0 || t.TLSHandshake < 0 || t.FirstByte < 0 || t.Total < 0 {
+			return fmt.Errorf("%w: timeouts must not be negative", ErrInvalidTimeout)
+		}
+		co.timeouts = t
+		return nil
+	}
+}
+
+// hasEarlierDeadline returns true if ctx has a deadline that expires before d has elapsed.
+func hasEarlierDeadline(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && !deadline.After(time.Now().Add(d))
+}
+
+// timeoutError returns a TimeoutError in place of err, if err was caused by ctx exceeding a
+// timeout. Otherwise, err is returned unchanged.
+func timeoutError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	var te *TimeoutError
+	if errors.As(err, &te) || !errors.As(context.Cause(ctx), &te) {
+		return err
+	}
+
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return &url.Error{Op: ue.Op, URL: ue.URL, Err: te}
+	}
+	return fmt.Errorf("%w", te)
+}
+
+// cancelBody is a response body that cancels the context of the request when closed, and reports
+// timeouts of the context as TimeoutErrors.
+type cancelBody struct {
+	io.ReadCloser
+	ctx    context.Context //nolint:containedctx
+	cancel func()
+}
+
+// Read reads from the underlying body.
+func (b *cancelBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = timeoutError(b.ctx, err)
+	}
+	return n, err
+}
+
+// Close closes the underlying body, and cancels the context.
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// withTotalTimeout returns a copy of req with the total timeout applied to its context, along with
+// a function to release associated resources.
+func (c *Client) withTotalTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	d := c.timeouts.Total
+	if d <= 0 || hasEarlierDeadline(req.Context(), d) {
+		return req, func() {}
+	}
+
+	ctx, cancel := context.WithTimeoutCause(req.Context(), d, &TimeoutError{Phase: TimeoutTotal, Duration: d})
+	return req.WithContext(ctx), cancel
+}
+
+// phaseTimers cancels the context of a request attempt when a connect, TLS handshake or first byte
+// timeout is exceeded.
+type phaseTimers struct {
+	ctx      context.Context //nolint:containedctx
+	cancel   context.CancelCauseFunc
+	timeouts Timeouts
+
+	mu      sync.Mutex
+	stopped bool
+	timers  map[string]*time.Timer
+}
+
+// start starts a timer identified by key, which cancels the attempt if it is not stopped within
+// timeout d of phase.
+func (p *phaseTimers) start(key string, phase TimeoutPhase, d time.Duration) {
+	if d <= 0 || hasEarlierDeadline(p.ctx, d) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stopped {
+		return
+	}
+	p.timers[key] = time.AfterFunc(d, func() {
+		p.cancel(&TimeoutError{Phase: phase, Duration: d})
+	})
+}
+
+// stop stops the timer identified by key.
+func (p *phaseTimers) stop(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t, ok := p.timers[key]; ok {
+		t.Stop()
+		delete(p.timers, key)
+	}
+}
+
+// stopAll stops all timers, and prevents further timers being started.
+func (p *phaseTimers) stopAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stopped = true
+	for key, t := range p.timers {
+		t.Stop()
+		delete(p.timers, key)
+	}
+}
+
+// clientTrace returns hooks that start and stop timers as the attempt progresses.
+func (p *phaseTimers) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		ConnectStart: func(network, addr string) {
+			p.start("connect "+network+" "+addr, TimeoutConnect, p.timeouts.Connect)
+		},
+		ConnectDone: func(network, addr string, _ error) {
+			p.stop("connect " + network + " " + addr)
+		},
+		TLSHandshakeStart: func() {
+			p.start("tls", TimeoutTLSHandshake, p.timeouts.TLSHandshake)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			p.stop("tls")
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			p.start("first byte", TimeoutFirstByte, p.timeouts.FirstByte)
+		},
+		GotFirstResponseByte: func() {
+			p.stop("first byte")
+		},
+	}
+}
+
+// withPhaseTimeouts returns a context derived from ctx, which is cancelled if the attempt exceeds
+// the connect, TLS handshake or first byte timeout. The returned function must be called with the
+// result of the attempt, and returns the result with timeouts reported as TimeoutErrors.
+func (c *Client) withPhaseTimeouts(ctx context.Context) (context.Context, func(*http.Response, error) (*http.Response, error)) {
+	t := c.timeouts
+	if t.Connect <= 0 && t.TLSHandshake <= 0 && t.FirstByte <= 0 {
+		return ctx, func(res *http.Response, err error) (*http.Response, error) { return res, err }
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	p := &phaseTimers{
+		ctx:      ctx,
+		cancel:   cancel,
+		timeouts: t,
+		timers:   make(map[string]*time.Timer),
+	}
+	ctx = httptrace.WithClientTrace(ctx, p.clientTrace())
+
+	return ctx, func(res *http.Response, err error) (*http.Response, error) {
+		p.stopAll()
+
+		if err != nil {
+			err = timeoutError(ctx, err)
+			cancel(nil)
+			return nil, err
+		}
+
+		res.Body = &cancelBody{ReadCloser: res.Body, ctx: ctx, cancel: func() { cancel(nil) }}
+		return res, nil
+	}
+}
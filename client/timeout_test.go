@@ -0,0 +1,296 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type MockStall struct {
+	stallHeaders int32 // Number of requests for which headers are delayed.
+	stallBody    bool  // Whether to delay the body.
+	requests     atomic.Int32
+}
+
+func (m *MockStall) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	stall := func() {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	if m.requests.Add(1) <= m.stallHeaders {
+		stall()
+		return
+	}
+
+	w.Header().Set("Content-Length", "6")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("key"))
+
+	if m.stallBody {
+		w.(http.Flusher).Flush()
+		stall()
+	}
+	_, _ = w.Write([]byte("key"))
+}
+
+func TestTimeouts(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctxTimeout   time.Duration
+		opts         []Option
+		stallHeaders int32
+		stallBody    bool
+		wantPhase    TimeoutPhase
+		wantTimeout  bool
+		wantRequests int32
+	}{
+		{
+			name:         "OK",
+			opts:         []Option{OptTimeouts(Timeouts{FirstByte: time.Second, Total: time.Second})},
+			wantRequests: 1,
+		},
+		{
+			name:         "FirstByte",
+			opts:         []Option{OptTimeouts(Timeouts{FirstByte: 50 * time.Millisecond, Total: time.Second})},
+			stallHeaders: 1,
+			wantPhase:    TimeoutFirstByte,
+			wantTimeout:  true,
+			wantRequests: 1,
+		},
+		{
+			name: "FirstByteRetry",
+			opts: []Option{
+				OptTimeouts(Timeouts{FirstByte: 50 * time.Millisecond}),
+				OptRetry(RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+			},
+			stallHeaders: 1,
+			wantRequests: 2,
+		},
+		{
+			name:         "TotalHeaders",
+			opts:         []Option{OptTimeouts(Timeouts{Total: 50 * time.Millisecond})},
+			stallHeaders: 1,
+			wantPhase:    TimeoutTotal,
+			wantTimeout:  true,
+			wantRequests: 1,
+		},
+		{
+			name:         "TotalBody",
+			opts:         []Option{OptTimeouts(Timeouts{FirstByte: time.Second, Total: 50 * time.Millisecond})},
+			stallBody:    true,
+			wantPhase:    TimeoutTotal,
+			wantTimeout:  true,
+			wantRequests: 1,
+		},
+		{
+			name:         "CallerDeadlineEarlier",
+			ctxTimeout:   50 * time.Millisecond,
+			opts:         []Option{OptTimeouts(Timeouts{FirstByte: time.Second, Total: time.Second})},
+			stallHeaders: 1,
+			wantTimeout:  true,
+			wantRequests: 1,
+		},
+		{
+			name:         "CallerDeadlineLater",
+			ctxTimeout:   time.Second,
+			opts:         []Option{OptTimeouts(Timeouts{FirstByte: 50 * time.Millisecond})},
+			stallHeaders: 1,
+			wantPhase:    TimeoutFirstByte,
+			wantTimeout:  true,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &MockStall{stallHeaders: tt.stallHeaders, stallBody: tt.stallBody}
+			s := httptest.NewServer(m)
+			defer s.Close()
+
+			c, err := NewClient(append([]Option{OptBaseURL(s.URL)}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			kt, err := c.GetKey(ctx, []byte{0x01, 0x23, 0x45, 0x67})
+
+			if got, want := errors.Is(err, context.DeadlineExceeded), tt.wantTimeout; got != want {
+				t.Fatalf("got deadline exceeded %v, want %v (error %v)", got, want, err)
+			}
+
+			var te *TimeoutError
+			if got, want := errors.As(err, &te), tt.wantPhase != ""; got != want {
+				t.Fatalf("got timeout error %v, want %v (error %v)", got, want, err)
+			}
+			if te != nil {
+				if got, want := te.Phase, tt.wantPhase; got != want {
+					t.Errorf("got phase %v, want %v", got, want)
+				}
+				if got, want := err.Error(), string(tt.wantPhase)+" timeout of"; !strings.Contains(got, want) {
+					t.Errorf("got error %q, want it to contain %q", got, want)
+				}
+			}
+
+			if err == nil {
+				if got, want := kt, "keykey"; got != want {
+					t.Errorf("got key text %v, want %v", got, want)
+				}
+			}
+
+			if got, want := m.requests.Load(), tt.wantRequests; got != want {
+				t.Errorf("got %v requests, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTimeoutsTLSHandshake(t *testing.T) {
+	// Accept connections, but never complete a TLS handshake.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer l.Close()
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	c, err := NewClient(
+		OptBaseURL(fmt.Sprintf("https://localhost:%d", l.Addr().(*net.TCPAddr).Port)),
+		OptHTTPClient(&http.Client{Transport: &http.Transport{}}),
+		OptTimeouts(Timeouts{Connect: time.Second, TLSHandshake: 50 * time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = c.GetKey(context.Background(), []byte{0x01, 0x23, 0x45, 0x67})
+
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("got error %v, want TimeoutError", err)
+	}
+	if got, want := te.Phase, TimeoutTLSHandshake; got != want {
+		t.Errorf("got phase %v, want %v", got, want)
+	}
+}
+
+func TestTimeoutsConnect(t *testing.T) {
+	// Delay connecting until the dial is abandoned.
+	d := &net.Dialer{
+		ControlContext: func(ctx context.Context, _, _ string, _ syscall.RawConn) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return errors.New("dial not abandoned")
+			}
+		},
+	}
+
+	c, err := NewClient(
+		OptBaseURL("http://localhost:11371"),
+		OptHTTPClient(&http.Client{Transport: &http.Transport{DialContext: d.DialContext}}),
+		OptTimeouts(Timeouts{Connect: 50 * time.Millisecond, Total: 5 * time.Second}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = c.GetKey(context.Background(), []byte{0x01, 0x23, 0x45, 0x67})
+
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("got error %v, want TimeoutError", err)
+	}
+	if got, want := te.Phase, TimeoutConnect; got != want {
+		t.Errorf("got phase %v, want %v", got, want)
+	}
+}
+
+func TestDefaultTimeouts(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want Timeouts
+	}{
+		{"Default", nil, Timeouts{Connect: 30 * time.Second, TLSHandshake: 10 * time.Second, FirstByte: time.Minute}},
+		{"Replaced", []Option{OptTimeouts(Timeouts{Total: time.Minute})}, Timeouts{Total: time.Minute}},
+		{"Disabled", []Option{OptTimeouts(Timeouts{})}, Timeouts{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := NewClient(tt.opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if got, want := c.timeouts, tt.want; got != want {
+				t.Errorf("got timeouts %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestOptTimeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeouts Timeouts
+		wantErr  error
+	}{
+		{"Zero", Timeouts{}, nil},
+		{"OK", Timeouts{Connect: time.Second, TLSHandshake: time.Second, FirstByte: time.Second, Total: time.Minute}, nil},
+		{"NegativeConnect", Timeouts{Connect: -1}, ErrInvalidTimeout},
+		{"NegativeTotal", Timeouts{Total: -1}, ErrInvalidTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(OptTimeouts(tt.timeouts))
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Errorf("got error %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer used to instrument the client.
+const tracerName = "github.com/sylabs/scs-key-client/client"
+
+// Span attribute keys.
+const (
+	attrOperation      = attribute.Key("hkp.operation")
+	attrSearchType     = attribute.Key("hkp.search.type")
+	attrPageToken      = attribute.Key("hkp.page_token.present")
+	attrLookupShared   = attribute.Key("hkp.lookup.shared")
+	attrAttempt        = attribute.Key("hkp.attempt")
+	attrMethod         = attribute.Key("http.request.method")
+	attrURLPath        = attribute.Key("url.path")
+	attrStatusCode     = attribute.Key("http.response.status_code")
+	attrResponseLength = attribute.Key("http.response.body.size")
+)
+
+// OptTracerProvider sets the provider of the tracer used to record a span for each logical
+// operation, and a child span for each HTTP request attempt. If not set, the global tracer
+// provider is used.
+//
+// The W3C trace context of each attempt is propagated to the Key Service.
+func OptTracerProvider(tp trace.TracerProvider) Option {
+	return func(co *clientOptions) error {
+		co.tracerProvider = tp
+		return nil
+	}
+}
+
+// operationKey is the context key used to record the name of the current operation.
+type operationKey struct{}
+
+// operationFromContext returns the name of the operation recorded in ctx, or an empty string.
+func operationFromContext(ctx context.Context) string {
+	op, _ := ctx.Value(operationKey{}).(string)
+	return op
+}
+
+// operation describes an in-progress logical operation.
+type operation struct {
+	ctx    context.Context //nolint:containedctx
+	name   string
+	nested bool // Whether the operation was started by another operation.
+	start  time.Time
+	span   trace.Span
+	c      *Client
+}
+
+// startOperation records the start of the named operation, returning a context that carries the
+// operation name and span. The caller must call end when the operation completes.
+func (c *Client) startOperation(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, *operation) {
+	nested := operationFromContext(ctx) != ""
+	ctx = context.WithValue(ctx, operationKey{}, name)
+
+	attrs = append(attrs, attrOperation.String(name))
+	ctx, span := c.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+
+	return ctx, &operation{ctx: ctx, name: name, nested: nested, start: time.Now(), span: span, c: c}
+}
+
+// end records the completion of op with the supplied error.
+func (op *operation) end(err error) {
+	if err != nil {
+		op.span.RecordError(err)
+		op.span.SetStatus(codes.Error, err.Error())
+	}
+	op.span.End()
+
+	d := time.Since(op.start)
+
+	// Failures are logged once, by the outermost operation.
+	if err != nil && !op.nested {
+		op.c.logOperationError(op.ctx, op.name, d, err)
+	}
+
+	if m := op.c.metrics; m != nil {
+		m.ObserveOperation(op.name, d, err)
+	}
+}
+
+// startAttempt starts a client span for an HTTP request attempt, and injects the resulting trace
+// context into the request headers.
+func (c *Client) startAttempt(ctx context.Context, method, path string, attempt int, h propagation.TextMapCarrier) (context.Context, trace.Span) {
+	ctx, span := c.tracer.Start(ctx, "HTTP "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attrOperation.String(operationFromContext(ctx)),
+			attrMethod.String(method),
+			attrURLPath.String(path),
+			attrAttempt.Int(attempt),
+		),
+	)
+
+	propagation.TraceContext{}.Inject(ctx, h)
+
+	return ctx, span
+}
+
+// searchType classifies the search term of lr, without revealing its value.
+func searchType(lr LookupRequest) string {
+	s := lr.Search
+
+	switch {
+	case s == "":
+		return "none"
+	case lr.Operation == OperationHGet:
+		return "hash"
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		switch len(s) - 2 {
+		case 8:
+			return "short_key_id"
+		case 16:
+			return "key_id"
+		case 32:
+			return "v3_fingerprint"
+		case 40:
+			return "v4_fingerprint"
+		case 64:
+			return "v6_fingerprint"
+		}
+		return "hex"
+	case strings.Contains(s, "@"):
+		return "email"
+	}
+	return "text"
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// spanAttr returns the value of the attribute of s with key k.
+func spanAttr(s sdktrace.ReadOnlySpan, k attribute.Key) (attribute.Value, bool) {
+	for _, kv := range s.Attributes() {
+		if kv.Key == k {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTracing(t *testing.T) {
+	var mu sync.Mutex
+	var traceparents []string
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		n := len(traceparents)
+		mu.Unlock()
+
+		// Fail the first attempt, to exercise retries.
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "key text")
+	}))
+	defer s.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	c, err := NewClient(
+		OptBaseURL(s.URL),
+		OptTracerProvider(tp),
+		OptRetry(RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.GetKey(context.Background(), mustDecodeHex(t, aliceFingerprint)); err != nil {
+		t.Fatalf("failed to get key: %v", err)
+	}
+
+	spans := sr.Ended()
+
+	byName := map[string][]sdktrace.ReadOnlySpan{}
+	for _, s := range spans {
+		byName[s.Name()] = append(byName[s.Name()], s)
+	}
+
+	if got, want := len(byName["GetKey"]), 1; got != want {
+		t.Fatalf("got %v GetKey spans, want %v", got, want)
+	}
+	if got, want := len(byName["PKSLookup"]), 1; got != want {
+		t.Fatalf("got %v PKSLookup spans, want %v", got, want)
+	}
+	if got, want := len(byName["HTTP GET"]), 2; got != want {
+		t.Fatalf("got %v attempt spans, want %v", got, want)
+	}
+
+	getKey := byName["GetKey"][0]
+	lookup := byName["PKSLookup"][0]
+
+	if got, want := lookup.Parent().SpanID(), getKey.SpanContext().SpanID(); got != want {
+		t.Errorf("got lookup parent %v, want %v", got, want)
+	}
+
+	for _, tc := range []struct {
+		span sdktrace.ReadOnlySpan
+		key  attribute.Key
+		want attribute.Value
+	}{
+		{getKey, attrOperation, attribute.StringValue("GetKey")},
+		{getKey, attrSearchType, attribute.StringValue("v4_fingerprint")},
+		{lookup, attrSearchType, attribute.StringValue("v4_fingerprint")},
+		{lookup, attrPageToken, attribute.BoolValue(false)},
+		{lookup, attrStatusCode, attribute.IntValue(http.StatusOK)},
+		{lookup, attrResponseLength, attribute.Int64Value(int64(len("key text")))},
+	} {
+		if got, ok := spanAttr(tc.span, tc.key); !ok || got != tc.want {
+			t.Errorf("got %v attribute %v = %v, want %v", tc.span.Name(), tc.key, got.Emit(), tc.want.Emit())
+		}
+	}
+
+	for i, attempt := range byName["HTTP GET"] {
+		if got, want := attempt.Parent().SpanID(), lookup.SpanContext().SpanID(); got != want {
+			t.Errorf("got attempt parent %v, want %v", got, want)
+		}
+
+		if got, want := traceparents[i], attempt.SpanContext().SpanID().String(); !strings.Contains(got, want) {
+			t.Errorf("got traceparent %q, want span ID %v", got, want)
+		}
+
+		if got, want := spanAttrInt(attempt, attrAttempt), int64(i+1); got != want {
+			t.Errorf("got attempt %v, want %v", got, want)
+		}
+	}
+
+	if got, want := byName["HTTP GET"][0].Status().Code, codes.Error; got != want {
+		t.Errorf("got status %v, want %v", got, want)
+	}
+}
+
+// spanAttrInt returns the integer value of the attribute of s with key k.
+func spanAttrInt(s sdktrace.ReadOnlySpan, k attribute.Key) int64 {
+	v, _ := spanAttr(s, k)
+	return v.AsInt64()
+}
+
+func TestSearchType(t *testing.T) {
+	tests := []struct {
+		lr   LookupRequest
+		want string
+	}{
+		{LookupRequest{Operation: OperationStats}, "none"},
+		{LookupRequest{Search: "ABCD", Operation: OperationHGet}, "hash"},
+		{LookupRequest{Search: "0x01020304"}, "short_key_id"},
+		{LookupRequest{Search: "0x0102030405060708"}, "key_id"},
+		{LookupRequest{Search: "0x" + aliceFingerprint}, "v4_fingerprint"},
+		{LookupRequest{Search: "0x01"}, "hex"},
+		{LookupRequest{Search: "alice@example.com"}, "email"},
+		{LookupRequest{Search: "Alice"}, "text"},
+	}
+
+	for _, tt := range tests {
+		if got := searchType(tt.lr); got != tt.want {
+			t.Errorf("got search type %v for %q, want %v", got, tt.lr.Search, tt.want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+const contentTypeForm = "application/x-www-form-urlencoded"
+
+// UploadEncoding describes how key text is encoded in the body of a streamed upload.
+type UploadEncoding int
+
+const (
+	// UploadFormURLEncoded encodes key text as application/x-www-form-urlencoded, as specified by
+	// the OpenPGP HTTP Keyserver Protocol (HKP) specification. Supported by all key servers.
+	UploadFormURLEncoded UploadEncoding = iota
+	// UploadMultipart encodes key text as multipart/form-data, which avoids percent-escaping the
+	// key text. Only supported by some key servers.
+	UploadMultipart
+)
+
+// PKSAddStreamOptions describes options for PKSAddStream.
+type PKSAddStreamOptions struct {
+	// Encoding of the request body (defaults to UploadFormURLEncoded).
+	Encoding UploadEncoding
+}
+
+// PKSAddStream submits the ASCII armored keyring read from r to the Key Service, as specified in
+// section 4 of the OpenPGP HTTP Keyserver Protocol (HKP) specification. If opts is nil, default
+// options are used. The context controls the lifetime of the request.
+//
+// The request body is encoded as r is read, so very large keyrings can be submitted using constant
+// memory. Machine readable output is requested, and the keys processed by the Key Service are
+// returned, as described by PKSAddWithResult.
+//
+// If r contains no data, an error wrapping ErrInvalidKeyText is returned. If opts specifies an
+// unknown encoding, an error wrapping ErrInvalidOperation is returned.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) PKSAddStream(ctx context.Context, r io.Reader, opts *PKSAddStreamOptions) (*AddResult, error) {
+	var o PKSAddStreamOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	options := []string{OptionMachineReadable}
+
+	var contentType string
+	var write func(io.Writer, io.Reader) error
+
+	switch o.Encoding {
+	case UploadFormURLEncoded:
+		contentType = contentTypeForm
+		write = func(w io.Writer, r io.Reader) error {
+			return writeFormURLEncoded(w, r, options)
+		}
+
+	case UploadMultipart:
+		// The boundary is chosen up front, since the content type is required before the body is
+		// written.
+		mw := multipart.NewWriter(nil)
+		boundary := mw.Boundary()
+
+		contentType = mw.FormDataContentType()
+		write = func(w io.Writer, r io.Reader) error {
+			return writeMultipart(w, boundary, r, options)
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: unknown encoding %v", ErrInvalidOperation, o.Encoding)
+	}
+
+	br, err := nonEmptyReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.postAdd(ctx, contentType, pipeBody(func(w io.Writer) error {
+		return write(w, br)
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := parseAddResult(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return res, nil
+}
+
+// nonEmptyReader returns a reader equivalent to r. If r contains no data, an error wrapping
+// ErrInvalidKeyText is returned.
+func nonEmptyReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+
+	if _, err := br.Peek(1); errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w", ErrInvalidKeyText)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return br, nil
+}
+
+// pipeBody returns a reader that yields the data written by write, which is called in a separate
+// goroutine. If write returns an error, it is returned to the reader. Closing the reader causes
+// subsequent writes to fail.
+func pipeBody(write func(io.Writer) error) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(write(pw))
+	}()
+
+	return pr
+}
+
+// writeFormURLEncoded writes an application/x-www-form-urlencoded form to w, with key text read
+// from r.
+func writeFormURLEncoded(w io.Writer, r io.Reader, options []string) error {
+	if len(options) > 0 {
+		v := url.Values{}
+		v.Set("options", strings.Join(options, ","))
+		if _, err := io.WriteString(w, v.Encode()+"&"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, "keytext="); err != nil {
+		return err
+	}
+
+	_, err := io.Copy(&queryEscapeWriter{w: w}, r)
+	return err
+}
+
+// writeMultipart writes a multipart/form-data form using the specified boundary to w, with key
+// text read from r.
+func writeMultipart(w io.Writer, boundary string, r io.Reader, options []string) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	if len(options) > 0 {
+		if err := mw.WriteField("options", strings.Join(options, ",")); err != nil {
+			return err
+		}
+	}
+
+	fw, err := mw.CreateFormField("keytext")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fw, r); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+// queryEscapeWriter escapes data written to it so it can be placed in a URL query or
+// application/x-www-form-urlencoded body.
+type queryEscapeWriter struct {
+	w io.Writer
+}
+
+// Write writes the escaped form of p to the underlying writer.
+func (qw *queryEscapeWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(qw.w, url.QueryEscape(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
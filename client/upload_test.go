@@ -0,0 +1,159 @@
+// Copyright (c) 2026, Sylabs Inc. All rights reserved.
+// This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
+// distributed with the sources of this project regarding your rights to use or distribute this
+// software.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// MockPKSAddStream accepts streamed uploads in the expected encoding.
+type MockPKSAddStream struct {
+	t         *testing.T
+	code      int
+	mediaType string
+	keyText   string
+}
+
+func (m *MockPKSAddStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.URL.Path, pathPKSAdd; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		m.t.Fatalf("failed to parse content type: %v", err)
+	}
+	if got, want := mediaType, m.mediaType; got != want {
+		m.t.Errorf("got media type %v, want %v", got, want)
+	}
+
+	if got, want := r.ContentLength, int64(-1); got != want {
+		m.t.Errorf("got content length %v, want %v", got, want)
+	}
+
+	if err := r.ParseMultipartForm(1 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		m.t.Fatalf("failed to parse form: %v", err)
+	}
+	if got, want := r.FormValue("keytext"), m.keyText; got != want {
+		m.t.Errorf("got key text %v, want %v", got, want)
+	}
+	if got, want := r.FormValue("options"), OptionMachineReadable; got != want {
+		m.t.Errorf("got options %v, want %v", got, want)
+	}
+
+	w.WriteHeader(m.code)
+	_, _ = w.Write([]byte(`{"inserted":["` + aliceFingerprint + `"]}`))
+}
+
+func TestPKSAddStream(t *testing.T) {
+	keyText := readTestKey(t, "alice.asc")
+
+	tests := []struct {
+		name          string
+		code          int
+		keyText       string
+		opts          *PKSAddStreamOptions
+		wantMediaType string
+		wantErr       error
+	}{
+		{
+			name:          "DefaultOptions",
+			code:          http.StatusOK,
+			keyText:       keyText,
+			wantMediaType: contentTypeForm,
+		},
+		{
+			name:          "Multipart",
+			code:          http.StatusOK,
+			keyText:       keyText,
+			opts:          &PKSAddStreamOptions{Encoding: UploadMultipart},
+			wantMediaType: "multipart/form-data",
+		},
+		{
+			name:          "HTTPError",
+			code:          http.StatusBadRequest,
+			keyText:       keyText,
+			wantMediaType: contentTypeForm,
+			wantErr:       &HTTPError{code: http.StatusBadRequest},
+		},
+		{
+			name:    "Empty",
+			code:    http.StatusOK,
+			wantErr: ErrInvalidKeyText,
+		},
+		{
+			name:    "InvalidEncoding",
+			code:    http.StatusOK,
+			keyText: keyText,
+			opts:    &PKSAddStreamOptions{Encoding: -1},
+			wantErr: ErrInvalidOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockPKSAddStream{
+				t:         t,
+				code:      tt.code,
+				mediaType: tt.wantMediaType,
+				keyText:   tt.keyText,
+			})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Deliver the key text a byte at a time, to exercise streaming.
+			r := iotest.OneByteReader(strings.NewReader(tt.keyText))
+
+			res, err := c.PKSAddStream(context.Background(), r, tt.opts)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			if err == nil {
+				if got := res.Fingerprints(AddStatusInserted); len(got) != 1 || got[0] != aliceFingerprint {
+					t.Errorf("got inserted %v, want %v", got, aliceFingerprint)
+				}
+			}
+		})
+	}
+}
+
+func TestPKSAddStreamReadError(t *testing.T) {
+	errRead := errors.New("read error")
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+	}))
+	defer s.Close()
+
+	c, err := NewClient(OptBaseURL(s.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	r := io.MultiReader(strings.NewReader("-----BEGIN"), iotest.ErrReader(errRead))
+	_, err = c.PKSAddStream(context.Background(), r, nil)
+
+	if got, want := err, errRead; !errors.Is(got, want) {
+		t.Errorf("got error %v, want %v", got, want)
+	}
+}
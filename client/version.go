@@ -1,4 +1,4 @@
-// Copyright (c) 2019-2020, Sylabs Inc. All rights reserved.
+// Copyright (c) 2019-2026, Sylabs Inc. All rights reserved.
 // This software is licensed under a 3-clause BSD license. Please consult the LICENSE.md file
 // distributed with the sources of this project regarding your rights to use or distribute this
 // software.
@@ -16,33 +16,173 @@ import (
 
 const pathVersion = "version"
 
+// ServerInfo describes the version and capabilities of the Key Service, as reported by its version
+// endpoint.
+//
+// Capabilities are only known if reported by the Key Service. A capability that is not reported is
+// nil, in which case features that depend on it should be gated on the version of the Key Service,
+// such as with RequireVersion.
+type ServerInfo struct {
+	// Version of the Key Service.
+	Version string
+	// Whether lookup pagination is supported, or nil if not reported.
+	Pagination *bool
+	// Whether machine readable ("mr") output is supported, or nil if not reported.
+	MachineReadable *bool
+	// Whether key deletion is supported, or nil if not reported.
+	Deletion *bool
+	// Maximum lookup page size, or nil if not reported.
+	MaxPageSize *int
+}
+
+// clone returns a deep copy of si.
+func (si *ServerInfo) clone() *ServerInfo {
+	cp := *si
+	cp.Pagination = clonePtr(si.Pagination)
+	cp.MachineReadable = clonePtr(si.MachineReadable)
+	cp.Deletion = clonePtr(si.Deletion)
+	cp.MaxPageSize = clonePtr(si.MaxPageSize)
+	return &cp
+}
+
+// clonePtr returns a pointer to a copy of the value pointed to by p, or nil if p is nil.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// CompareVersion returns -1, 0 or +1 depending on whether the version of the Key Service is less
+// than, equal to, or greater than semantic version v. If either version cannot be parsed, an error
+// wrapping ErrInvalidVersion is returned.
+func (si *ServerInfo) CompareVersion(v string) (int, error) {
+	return CompareVersions(si.Version, v)
+}
+
+// serverInfoResponse is the response to a version request.
+type serverInfoResponse struct {
+	Version      string `json:"version"`
+	Capabilities struct {
+		Pagination      *bool `json:"pagination"`
+		MachineReadable *bool `json:"machineReadable"`
+		Deletion        *bool `json:"deletion"`
+		MaxPageSize     *int  `json:"maxPageSize"`
+	} `json:"capabilities"`
+}
+
 // GetVersion gets version information from the Key Service. The context controls the lifetime of
 // the request.
 //
+// The server information cached by the Client is updated with the response.
+//
 // If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
 func (c *Client) GetVersion(ctx context.Context) (string, error) {
+	si, err := c.fetchServerInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return si.Version, nil
+}
+
+// GetServerInfo returns the version and capabilities of the Key Service. Server information is
+// retrieved on first use, and cached by the Client for subsequent calls until InvalidateServerInfo
+// is called. The context controls the lifetime of the request, if one is made.
+//
+// If an non-200 HTTP status code is received, an error wrapping an HTTPError is returned.
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	c.serverInfoMu.Lock()
+	si := c.serverInfo
+	c.serverInfoMu.Unlock()
+
+	if si != nil {
+		c.observeCache(CacheServerInfo, true)
+	} else {
+		var shared bool
+		var err error
+
+		si, shared, err = c.serverInfoGroup.do(ctx, pathVersion, c.fetchServerInfo)
+		c.observeCache(CacheServerInfo, shared)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return a copy, so that callers cannot modify the cached value.
+	return si.clone(), nil
+}
+
+// InvalidateServerInfo discards the server information cached by the Client, so that it is
+// retrieved again on next use. This is useful when the Key Service may have been upgraded.
+func (c *Client) InvalidateServerInfo() {
+	c.serverInfoMu.Lock()
+	defer c.serverInfoMu.Unlock()
+
+	c.serverInfo = nil
+}
+
+// RequireVersion returns an error if the version of the Key Service does not satisfy constraint.
+// A constraint consists of one or more comma-separated comparisons using the operators "=", "!=",
+// ">", ">=", "<" and "<=", all of which must be satisfied, such as ">= 1.2, < 2". Server
+// information is retrieved as described by GetServerInfo.
+//
+// If the version is not satisfied, an error wrapping ErrVersionUnsatisfied is returned. If the
+// version or constraint cannot be parsed, an error wrapping ErrInvalidVersion is returned.
+func (c *Client) RequireVersion(ctx context.Context, constraint string) error {
+	si, err := c.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	ok, err := satisfiesConstraint(si.Version, constraint)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: version %v does not satisfy %q", ErrVersionUnsatisfied, si.Version, constraint)
+	}
+	return nil
+}
+
+// fetchServerInfo retrieves server information from the Key Service, and caches the result.
+func (c *Client) fetchServerInfo(ctx context.Context) (si *ServerInfo, err error) {
+	ctx, op := c.startOperation(ctx, "GetVersion")
+	defer func() { op.end(err) }()
+
 	ref := &url.URL{Path: pathVersion}
 
 	req, err := c.NewRequest(ctx, http.MethodGet, ref, nil)
 	if err != nil {
-		return "", fmt.Errorf("%w", err)
+		return nil, fmt.Errorf("%w", err)
 	}
 
 	res, err := c.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("%w", err)
+		return nil, fmt.Errorf("%w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode/100 != 2 { // non-2xx status code
-		return "", fmt.Errorf("%w", errorFromResponse(res))
+		return nil, fmt.Errorf("%w", c.errorFromResponse(res))
+	}
+
+	var sr serverInfoResponse
+	if err := jsonresp.ReadResponse(res.Body, &sr); err != nil {
+		return nil, fmt.Errorf("%w", err)
 	}
 
-	vi := struct {
-		Version string `json:"version"`
-	}{}
-	if err := jsonresp.ReadResponse(res.Body, &vi); err != nil {
-		return "", fmt.Errorf("%w", err)
+	si = &ServerInfo{
+		Version:         sr.Version,
+		Pagination:      sr.Capabilities.Pagination,
+		MachineReadable: sr.Capabilities.MachineReadable,
+		Deletion:        sr.Capabilities.Deletion,
+		MaxPageSize:     sr.Capabilities.MaxPageSize,
 	}
-	return vi.Version, nil
+
+	c.serverInfoMu.Lock()
+	c.serverInfo = si
+	c.serverInfoMu.Unlock()
+
+	return si, nil
 }
@@ -8,8 +8,11 @@ package client
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sync"
 	"testing"
 
 	jsonresp "github.com/sylabs/json-resp"
@@ -139,3 +142,190 @@ func TestGetVersion(t *testing.T) {
 		})
 	}
 }
+
+type MockServerInfo struct {
+	t        *testing.T
+	code     int
+	response string
+
+	mu       sync.Mutex
+	requests int
+}
+
+func (m *MockServerInfo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	m.requests++
+	m.mu.Unlock()
+
+	if got, want := r.URL.Path, "/version"; got != want {
+		m.t.Errorf("got path %v, want %v", got, want)
+	}
+
+	w.WriteHeader(m.code)
+	if _, err := io.WriteString(w, m.response); err != nil {
+		m.t.Fatalf("failed to write response: %v", err)
+	}
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestGetServerInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		response string
+		wantErr  error
+		wantInfo *ServerInfo
+	}{
+		{
+			name:     "VersionOnly",
+			code:     http.StatusOK,
+			response: `{"data":{"version":"1.2.3"}}`,
+			wantInfo: &ServerInfo{Version: "1.2.3"},
+		},
+		{
+			name:     "Capabilities",
+			code:     http.StatusOK,
+			response: `{"data":{"version":"1.2.3","capabilities":{"pagination":true,"machineReadable":true,"deletion":false,"maxPageSize":100}}}`,
+			wantInfo: &ServerInfo{
+				Version:         "1.2.3",
+				Pagination:      ptr(true),
+				MachineReadable: ptr(true),
+				Deletion:        ptr(false),
+				MaxPageSize:     ptr(100),
+			},
+		},
+		{
+			name:     "PartialCapabilities",
+			code:     http.StatusOK,
+			response: `{"data":{"version":"1.2.3","capabilities":{"deletion":true}}}`,
+			wantInfo: &ServerInfo{Version: "1.2.3", Deletion: ptr(true)},
+		},
+		{
+			name:     "UnknownFields",
+			code:     http.StatusOK,
+			response: `{"data":{"version":"1.2.3","other":true}}`,
+			wantInfo: &ServerInfo{Version: "1.2.3"},
+		},
+		{
+			name:    "HTTPError",
+			code:    http.StatusBadRequest,
+			wantErr: &HTTPError{code: http.StatusBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := MockServerInfo{t: t, code: tt.code, response: tt.response}
+
+			s := httptest.NewServer(&m)
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Make multiple calls, to verify caching.
+			for i := 0; i < 2; i++ {
+				si, err := c.GetServerInfo(context.Background())
+
+				if got, want := err, tt.wantErr; !errors.Is(got, want) {
+					t.Fatalf("got error %v, want %v", got, want)
+				}
+
+				if err == nil {
+					if got, want := si, tt.wantInfo; !reflect.DeepEqual(got, want) {
+						t.Errorf("got info %+v, want %+v", got, want)
+					}
+
+					// Modifying the result does not affect the cached value.
+					si.Version = "modified"
+					if si.Deletion != nil {
+						*si.Deletion = !*si.Deletion
+					}
+				}
+			}
+
+			// Errors are not cached.
+			wantRequests := 1
+			if tt.wantErr != nil {
+				wantRequests = 2
+			}
+			if got, want := m.requests, wantRequests; got != want {
+				t.Errorf("got %v requests, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestInvalidateServerInfo(t *testing.T) {
+	m := MockServerInfo{t: t, code: http.StatusOK, response: `{"data":{"version":"1.2.3"}}`}
+
+	s := httptest.NewServer(&m)
+	defer s.Close()
+
+	c, err := NewClient(OptBaseURL(s.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for _, invalidate := range []bool{false, false, true, false} {
+		if invalidate {
+			c.InvalidateServerInfo()
+		}
+
+		if _, err := c.GetServerInfo(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got, want := m.requests, 2; got != want {
+		t.Errorf("got %v requests, want %v", got, want)
+	}
+}
+
+func TestRequireVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		constraint string
+		wantErr    error
+	}{
+		{"Satisfied", "1.2.3", ">= 1.2", nil},
+		{"Unsatisfied", "1.2.3", ">= 2", ErrVersionUnsatisfied},
+		{"InvalidConstraint", "1.2.3", ">= two", ErrInvalidVersion},
+		{"InvalidVersion", "unknown", ">= 1", ErrInvalidVersion},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := httptest.NewServer(&MockServerInfo{
+				t:        t,
+				code:     http.StatusOK,
+				response: `{"data":{"version":"` + tt.version + `"}}`,
+			})
+			defer s.Close()
+
+			c, err := NewClient(OptBaseURL(s.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			err = c.RequireVersion(context.Background(), tt.constraint)
+
+			if got, want := err, tt.wantErr; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+		})
+	}
+}